@@ -83,6 +83,12 @@ func (s *OrderService) CreateOrder(customerName, customerEmail, productID, produ
 		ProductID:      productID,
 		ProductTokenID: productTokenID,
 		Count:          quantity,
+		PaymentOptions: client.PaymentOptions{
+			MerchantReference: orderNumber,
+			Metadata: map[string]string{
+				"customer_email": customerEmail,
+			},
+		},
 	}
 
 	reddioResp, err := s.client.ExternalCreatePayment(reddioReq)
@@ -0,0 +1,204 @@
+package checkout
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/internal/keccak"
+)
+
+// Selector returns the 4-byte function selector of a canonical signature such as "approve(address,uint256)"
+func Selector(signature string) [4]byte {
+	h := keccak.Sum256([]byte(signature))
+	var sel [4]byte
+	copy(sel[:], h[:4])
+	return sel
+}
+
+// EncodeCall ABI-encodes a contract call: the selector of signature followed by args.
+// Supported types are address, uint256, bool, bytes32, string, bytes and arrays of
+// address, uint256 and bytes32. Arguments are given as client.Address, *big.Int,
+// bool, [32]byte, string, []byte, []client.Address, []*big.Int and [][32]byte.
+func EncodeCall(signature string, args ...interface{}) ([]byte, error) {
+	types, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) != len(args) {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", signature, len(types), len(args))
+	}
+	encoded, err := encodeArgs(types, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", signature, err)
+	}
+	sel := Selector(signature)
+	return append(sel[:], encoded...), nil
+}
+
+func parseSignature(signature string) ([]string, error) {
+	open := strings.IndexByte(signature, '(')
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return nil, fmt.Errorf("invalid function signature %q", signature)
+	}
+	params := signature[open+1 : len(signature)-1]
+	if params == "" {
+		return nil, nil
+	}
+	types := strings.Split(params, ",")
+	for _, t := range types {
+		if strings.ContainsAny(t, "() ") {
+			return nil, fmt.Errorf("unsupported parameter type %q in %q", t, signature)
+		}
+	}
+	return types, nil
+}
+
+// encodeArgs applies the head/tail layout: static values are stored inline,
+// dynamic values are stored after the head and referenced by offset
+func encodeArgs(types []string, args []interface{}) ([]byte, error) {
+	head := make([]byte, 0, 32*len(types))
+	var tail []byte
+	for i, typ := range types {
+		if isDynamic(typ) {
+			enc, err := encodeDynamic(typ, args[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			head = append(head, uint256Word(big.NewInt(int64(32*len(types)+len(tail))))...)
+			tail = append(tail, enc...)
+			continue
+		}
+		word, err := encodeStatic(typ, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		head = append(head, word...)
+	}
+	return append(head, tail...), nil
+}
+
+func isDynamic(typ string) bool {
+	return typ == "string" || typ == "bytes" || strings.HasSuffix(typ, "[]")
+}
+
+func encodeStatic(typ string, arg interface{}) ([]byte, error) {
+	switch typ {
+	case "address":
+		addr, ok := arg.(client.Address)
+		if !ok {
+			return nil, fmt.Errorf("address expects client.Address, got %T", arg)
+		}
+		b := addr.Bytes()
+		if b == nil {
+			return nil, fmt.Errorf("%w: %q", client.ErrInvalidAddress, addr)
+		}
+		return leftPad(b), nil
+	case "uint256":
+		v, ok := arg.(*big.Int)
+		if !ok || v == nil {
+			return nil, fmt.Errorf("uint256 expects *big.Int, got %T", arg)
+		}
+		if v.Sign() < 0 || v.BitLen() > 256 {
+			return nil, fmt.Errorf("value %s out of uint256 range", v)
+		}
+		return uint256Word(v), nil
+	case "bool":
+		b, ok := arg.(bool)
+		if !ok {
+			return nil, fmt.Errorf("bool expects bool, got %T", arg)
+		}
+		if b {
+			return uint256Word(big.NewInt(1)), nil
+		}
+		return uint256Word(new(big.Int)), nil
+	case "bytes32":
+		b, ok := arg.([32]byte)
+		if !ok {
+			return nil, fmt.Errorf("bytes32 expects [32]byte, got %T", arg)
+		}
+		return b[:], nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+func encodeDynamic(typ string, arg interface{}) ([]byte, error) {
+	switch typ {
+	case "string":
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("string expects string, got %T", arg)
+		}
+		return encodeBytes([]byte(s)), nil
+	case "bytes":
+		b, ok := arg.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("bytes expects []byte, got %T", arg)
+		}
+		return encodeBytes(b), nil
+	}
+
+	elem := strings.TrimSuffix(typ, "[]")
+	var items []interface{}
+	switch v := arg.(type) {
+	case []client.Address:
+		for _, a := range v {
+			items = append(items, a)
+		}
+	case []*big.Int:
+		for _, n := range v {
+			items = append(items, n)
+		}
+	case [][32]byte:
+		for _, b := range v {
+			items = append(items, b)
+		}
+	default:
+		return nil, fmt.Errorf("%s expects a slice, got %T", typ, arg)
+	}
+	if isDynamic(elem) {
+		return nil, fmt.Errorf("unsupported array element type %s", elem)
+	}
+	out := uint256Word(big.NewInt(int64(len(items))))
+	for _, item := range items {
+		word, err := encodeStatic(elem, item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, word...)
+	}
+	return out, nil
+}
+
+func encodeBytes(b []byte) []byte {
+	out := uint256Word(big.NewInt(int64(len(b))))
+	padded := make([]byte, (len(b)+31)/32*32)
+	copy(padded, b)
+	return append(out, padded...)
+}
+
+func uint256Word(v *big.Int) []byte {
+	word := make([]byte, 32)
+	return v.FillBytes(word)
+}
+
+func leftPad(b []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+	return word
+}
+
+// hexData encodes calldata the way wallets expect it
+func hexData(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// hexQuantity encodes a value as a JSON-RPC quantity
+func hexQuantity(v *big.Int) string {
+	if v == nil {
+		return "0x0"
+	}
+	return "0x" + v.Text(16)
+}
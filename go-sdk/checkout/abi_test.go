@@ -0,0 +1,91 @@
+package checkout
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+)
+
+func words(ws ...string) string {
+	var sb strings.Builder
+	for _, w := range ws {
+		sb.WriteString(strings.Repeat("0", 64-len(w)) + w)
+	}
+	return sb.String()
+}
+
+func TestSelector(t *testing.T) {
+	tests := map[string]string{
+		"approve(address,uint256)":              "095ea7b3",
+		"transfer(address,uint256)":             "a9059cbb",
+		"allowance(address,address)":            "dd62ed3e",
+		"transferFrom(address,address,uint256)": "23b872dd",
+	}
+	for sig, want := range tests {
+		sel := Selector(sig)
+		if got := hex.EncodeToString(sel[:]); got != want {
+			t.Errorf("Selector(%s) = %s, want %s", sig, got, want)
+		}
+	}
+}
+
+func TestEncodeCallStatic(t *testing.T) {
+	spender := client.Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	data, err := EncodeCall(ApproveMethod, spender, big.NewInt(1000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "095ea7b3" + words("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "f4240")
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("approve calldata\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestEncodeCallDynamic(t *testing.T) {
+	// Example from the Solidity ABI specification
+	data, err := EncodeCall("sam(bytes,bool,uint256[])", []byte("dave"), true,
+		[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a5643bf2" + words("60", "1", "a0", "4") + "6461766500000000000000000000000000000000000000000000000000000000" +
+		words("3", "1", "2", "3")
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("sam calldata\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestEncodeCallMixed(t *testing.T) {
+	a := client.Address("0x52908400098527886E0F7030069857D2E4169EE7")
+	b := client.Address("0x8617E340B3D01FA5F11F306F4090FD50E238070D")
+	data, err := EncodeCall("pay(string,address,address[],uint256[])", "abc", a,
+		[]client.Address{a, b}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	aw, bw := "52908400098527886e0f7030069857d2e4169ee7", "8617e340b3d01fa5f11f306f4090fd50e238070d"
+	// 头部 4 个字：字符串偏移、地址、两个数组偏移
+	want := words("80", aw, "c0", "120") +
+		words("3") + "6162630000000000000000000000000000000000000000000000000000000000" +
+		words("2", aw, bw) +
+		words("2", "1", "2")
+	if got := hex.EncodeToString(data[4:]); got != want {
+		t.Errorf("pay arguments\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestEncodeCallErrors(t *testing.T) {
+	if _, err := EncodeCall(ApproveMethod, client.Address("zz5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"), big.NewInt(1)); !errors.Is(err, client.ErrInvalidAddress) {
+		t.Errorf("address without 0x: got %v, want ErrInvalidAddress", err)
+	}
+	if _, err := EncodeCall(ApproveMethod, client.Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"), big.NewInt(-1)); err == nil {
+		t.Error("negative uint256: expected an error")
+	}
+	if _, err := EncodeCall(ApproveMethod, client.Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")); err == nil {
+		t.Error("missing argument: expected an error")
+	}
+}
@@ -0,0 +1,211 @@
+// Package checkout builds the unsigned wallet transactions needed to pay a Reddio Pay
+// payment directly through the payment router, for merchants that run their own
+// wallet checkout instead of redirecting the customer to the PayLink.
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/onchain"
+)
+
+// ERC-20 methods used by the checkout
+const (
+	ApproveMethod   = "approve(address,uint256)"
+	AllowanceMethod = "allowance(address,address)"
+)
+
+// ErrNoPayMethod is returned by Build when the router method is not configured.
+// The payment router ABI is not part of the API response, so it must be supplied
+// from the router contract the payment's ContractAddress points to.
+var ErrNoPayMethod = errors.New("checkout: Builder.PayMethod and Builder.PayArgs are required")
+
+// TransactionRequest is an unsigned transaction in the shape accepted by
+// eth_sendTransaction and most wallet libraries
+type TransactionRequest struct {
+	ChainID string         `json:"chainId,omitempty"` // 0x-prefixed quantity
+	From    client.Address `json:"from,omitempty"`
+	To      client.Address `json:"to"`
+	Data    string         `json:"data"`  // 0x-prefixed calldata
+	Value   string         `json:"value"` // 0x-prefixed wei quantity
+	// Description is a label for the checkout UI and is not sent to the wallet
+	Description string `json:"-"`
+}
+
+// PayArgsFunc returns the arguments of the router payment call for PayMethod
+type PayArgsFunc func(resp *client.ExternalCreatePaymentResponse, recipients []client.Address, amounts []*big.Int) ([]interface{}, error)
+
+// Builder turns payment creation responses into wallet transactions
+type Builder struct {
+	// ChainID is set on every transaction if non-zero
+	ChainID int
+	// PayMethod is the router method signature taken from the router ABI, such as
+	// "pay(string,address,address[],uint256[])"; required
+	PayMethod string
+	// PayArgs builds the arguments for PayMethod; required
+	PayArgs PayArgsFunc
+}
+
+// Plan is the ordered list of transactions that pays one payment
+type Plan struct {
+	PaymentID string
+	Router    client.Address
+	Token     client.Address
+	Decimals  int
+	// Total is the sum of every receiver amount, in base units
+	Total *big.Int
+	// Native is set when the payment's token address is the zero address, which
+	// means the payment is made in the chain's native currency
+	Native bool
+	// Approve allows the router to spend Total; nil for native payments
+	Approve *TransactionRequest
+	Pay     *TransactionRequest
+}
+
+// Transactions returns the transactions to send, in order
+func (p *Plan) Transactions() []*TransactionRequest {
+	if p.Approve == nil {
+		return []*TransactionRequest{p.Pay}
+	}
+	return []*TransactionRequest{p.Approve, p.Pay}
+}
+
+// TotalAmount returns Total as a lossless amount
+func (p *Plan) TotalAmount() client.Amount {
+	return client.NewAmount(p.Total, p.Decimals)
+}
+
+// Build creates the approve and router payment transactions for a payment. from is the
+// customer's wallet address and may be empty if the wallet fills it in.
+func (b *Builder) Build(resp *client.ExternalCreatePaymentResponse, from client.Address) (*Plan, error) {
+	if resp == nil {
+		return nil, errors.New("nil payment response")
+	}
+	if b.PayMethod == "" || b.PayArgs == nil {
+		return nil, ErrNoPayMethod
+	}
+	router, err := client.ParseAddress(string(resp.ContractAddress))
+	if err != nil {
+		return nil, fmt.Errorf("payment router address: %w", err)
+	}
+	if from != "" {
+		if from, err = client.ParseAddress(string(from)); err != nil {
+			return nil, fmt.Errorf("from address: %w", err)
+		}
+	}
+	if len(resp.PaymentReceivers) == 0 {
+		return nil, fmt.Errorf("payment %s has no receivers", resp.PaymentID)
+	}
+
+	// 缺少代币地址时不能当作原生币支付，只有显式的零地址才是原生币
+	if strings.TrimSpace(string(resp.TokenAddress)) == "" {
+		return nil, fmt.Errorf("payment %s has no token address", resp.PaymentID)
+	}
+	token, err := client.ParseAddress(string(resp.TokenAddress))
+	if err != nil {
+		return nil, fmt.Errorf("token address: %w", err)
+	}
+	plan := &Plan{
+		PaymentID: resp.PaymentID,
+		Router:    router,
+		Decimals:  resp.Decimals,
+		Total:     new(big.Int),
+		Native:    token.IsZero(),
+	}
+	if !plan.Native {
+		plan.Token = token
+	}
+
+	recipients := make([]client.Address, 0, len(resp.PaymentReceivers))
+	amounts := make([]*big.Int, 0, len(resp.PaymentReceivers))
+	for _, r := range resp.PaymentReceivers {
+		addr, err := client.ParseAddress(string(r.RecipientAddress))
+		if err != nil {
+			return nil, fmt.Errorf("%s receiver: %w", r.Type, err)
+		}
+		amount, ok := new(big.Int).SetString(r.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("%s receiver: invalid amount %q", r.Type, r.Amount)
+		}
+		recipients = append(recipients, addr)
+		amounts = append(amounts, amount)
+		plan.Total.Add(plan.Total, amount)
+	}
+
+	args, err := b.PayArgs(resp, recipients, amounts)
+	if err != nil {
+		return nil, err
+	}
+	payData, err := EncodeCall(b.PayMethod, args...)
+	if err != nil {
+		return nil, err
+	}
+	value := new(big.Int)
+	if plan.Native {
+		value = plan.Total
+	}
+	plan.Pay = &TransactionRequest{
+		ChainID:     b.chainID(),
+		From:        from,
+		To:          router,
+		Data:        hexData(payData),
+		Value:       hexQuantity(value),
+		Description: "Pay " + plan.TotalAmount().String(),
+	}
+
+	if !plan.Native {
+		// 只授权本次支付所需的金额，不做无限授权
+		approveData, err := EncodeCall(ApproveMethod, router, plan.Total)
+		if err != nil {
+			return nil, err
+		}
+		plan.Approve = &TransactionRequest{
+			ChainID:     b.chainID(),
+			From:        from,
+			To:          plan.Token,
+			Data:        hexData(approveData),
+			Value:       "0x0",
+			Description: "Approve " + plan.TotalAmount().String(),
+		}
+	}
+	return plan, nil
+}
+
+func (b *Builder) chainID() string {
+	if b.ChainID == 0 {
+		return ""
+	}
+	return hexQuantity(big.NewInt(int64(b.ChainID)))
+}
+
+// ApprovalNeeded queries the token allowance of owner for the router and reports
+// whether the approve transaction must be sent before the payment
+func (p *Plan) ApprovalNeeded(ctx context.Context, rpc onchain.RPCClient, owner client.Address) (bool, error) {
+	if p.Native {
+		return false, nil
+	}
+	data, err := EncodeCall(AllowanceMethod, owner, p.Router)
+	if err != nil {
+		return false, err
+	}
+	call := map[string]string{"to": string(p.Token), "data": hexData(data)}
+	var result string
+	if err := rpc.Call(ctx, &result, "eth_call", call, "latest"); err != nil {
+		return false, fmt.Errorf("failed to query allowance: %w", err)
+	}
+	// 合约不存在时节点返回 "0x"，视为零授权
+	digits := strings.TrimPrefix(result, "0x")
+	if digits == "" {
+		digits = "0"
+	}
+	allowance, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return false, fmt.Errorf("invalid allowance %q", result)
+	}
+	return allowance.Cmp(p.Total) < 0, nil
+}
@@ -0,0 +1,116 @@
+package checkout
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/qrcode"
+)
+
+// ErrMultipleReceivers is returned by PaymentURI when a payment splits its amount between
+// several receivers, which a single EIP-681 transfer cannot express
+var ErrMultipleReceivers = errors.New("payment has several receivers, use the PayLink instead")
+
+// TransferURI builds an EIP-681 URI for an ERC-20 transfer of amount base units of token to recipient.
+// chainID is omitted from the URI if zero.
+func TransferURI(token client.Address, chainID int, recipient client.Address, amount *big.Int) (string, error) {
+	token, err := client.ParseAddress(string(token))
+	if err != nil {
+		return "", fmt.Errorf("token address: %w", err)
+	}
+	recipient, err = client.ParseAddress(string(recipient))
+	if err != nil {
+		return "", fmt.Errorf("recipient address: %w", err)
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return "", fmt.Errorf("amount must be positive")
+	}
+	q := url.Values{}
+	q.Set("address", string(recipient))
+	q.Set("uint256", amount.String())
+	return "ethereum:" + string(token) + chainSuffix(chainID) + "/transfer?" + q.Encode(), nil
+}
+
+// NativeURI builds an EIP-681 URI for sending value wei of the chain's native currency to recipient
+func NativeURI(recipient client.Address, chainID int, value *big.Int) (string, error) {
+	recipient, err := client.ParseAddress(string(recipient))
+	if err != nil {
+		return "", fmt.Errorf("recipient address: %w", err)
+	}
+	if value == nil || value.Sign() <= 0 {
+		return "", fmt.Errorf("value must be positive")
+	}
+	return "ethereum:" + string(recipient) + chainSuffix(chainID) + "?value=" + value.String(), nil
+}
+
+func chainSuffix(chainID int) string {
+	if chainID == 0 {
+		return ""
+	}
+	return "@" + strconv.Itoa(chainID)
+}
+
+// PaymentURI builds an EIP-681 URI that pays a payment with a plain transfer. The transfer
+// bypasses the payment router and carries no payment ID, so it is not matched to the payment
+// automatically and the payment stays pending; only use it if you reconcile such transfers
+// yourself, otherwise use the PayLink. This only works when the whole amount goes to one
+// receiver; receivers with a zero amount are ignored. For payments split between merchant
+// and fee receivers ErrMultipleReceivers is returned.
+func PaymentURI(resp *client.ExternalCreatePaymentResponse, chainID int) (string, error) {
+	var (
+		recipient client.Address
+		amount    *big.Int
+	)
+	for _, r := range resp.PaymentReceivers {
+		v, ok := new(big.Int).SetString(r.Amount, 10)
+		if !ok {
+			return "", fmt.Errorf("%s receiver: invalid amount %q", r.Type, r.Amount)
+		}
+		if v.Sign() == 0 {
+			continue
+		}
+		if amount != nil && !recipient.Equal(r.RecipientAddress) {
+			return "", fmt.Errorf("payment %s: %w", resp.PaymentID, ErrMultipleReceivers)
+		}
+		if amount == nil {
+			recipient, amount = r.RecipientAddress, new(big.Int)
+		}
+		amount.Add(amount, v)
+	}
+	if amount == nil {
+		return "", fmt.Errorf("payment %s has no receiver to pay", resp.PaymentID)
+	}
+	if strings.TrimSpace(string(resp.TokenAddress)) == "" {
+		return "", fmt.Errorf("payment %s has no token address", resp.PaymentID)
+	}
+	if resp.TokenAddress.IsZero() {
+		return NativeURI(recipient, chainID, amount)
+	}
+	return TransferURI(resp.TokenAddress, chainID, recipient, amount)
+}
+
+// ErrNoPayLink is returned by PaymentQRCode when the payment response has no PayLink
+var ErrNoPayLink = errors.New("payment has no PayLink")
+
+// PaymentQRCode encodes the payment's PayLink as a QR code
+func PaymentQRCode(resp *client.ExternalCreatePaymentResponse, level qrcode.Level) (*qrcode.Code, error) {
+	if resp.PayLink == "" {
+		return nil, fmt.Errorf("payment %s: %w", resp.PaymentID, ErrNoPayLink)
+	}
+	return qrcode.Encode(resp.PayLink, level)
+}
+
+// TransferQRCode encodes the payment's EIP-681 URI as a QR code. Like PaymentURI, the
+// transfer is not matched to the payment automatically.
+func TransferQRCode(resp *client.ExternalCreatePaymentResponse, chainID int, level qrcode.Level) (*qrcode.Code, error) {
+	text, err := PaymentURI(resp, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.Encode(text, level)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -41,29 +42,29 @@ type UpdateAccountInfoResponse struct {
 
 // BalanceRequest represents the request body for balance query
 type BalanceRequest struct {
-	WalletAddress string `json:"wallet_address"`
-	ChainID       int    `json:"chain_id"`
-	TokenSymbol   string `json:"token_symbol,omitempty"` // 可选，如果不提供则查询所有支持的代币
+	WalletAddress Address `json:"wallet_address"`
+	ChainID       int     `json:"chain_id"`
+	TokenSymbol   string  `json:"token_symbol,omitempty"` // 可选，如果不提供则查询所有支持的代币
 }
 
 // TokenBalance represents a single token balance
 type TokenBalance struct {
-	TokenID          string `json:"token_id"`
-	Name             string `json:"name"`
-	Symbol           string `json:"symbol"`
-	ContractAddress  string `json:"contract_address"`
-	Decimals         int    `json:"decimals"`
-	Balance          string `json:"balance"`           // 原始余额（wei等单位）
-	FormattedBalance string `json:"formatted_balance"` // 格式化后的余额（人类可读）
-	ChainID          int    `json:"chain_id"`
-	ChainName        string `json:"chain_name"`
-	ChainSymbol      string `json:"chain_symbol"`
-	IconURL          string `json:"icon_url"` // 代币图标URL
+	TokenID          string  `json:"token_id"`
+	Name             string  `json:"name"`
+	Symbol           string  `json:"symbol"`
+	ContractAddress  Address `json:"contract_address"`
+	Decimals         int     `json:"decimals"`
+	Balance          string  `json:"balance"`           // 原始余额（wei等单位）
+	FormattedBalance string  `json:"formatted_balance"` // 格式化后的余额（人类可读）
+	ChainID          int     `json:"chain_id"`
+	ChainName        string  `json:"chain_name"`
+	ChainSymbol      string  `json:"chain_symbol"`
+	IconURL          string  `json:"icon_url"` // 代币图标URL
 }
 
 // BalanceResponse represents the response for balance query
 type BalanceResponse struct {
-	WalletAddress string         `json:"wallet_address"`
+	WalletAddress Address        `json:"wallet_address"`
 	ChainID       int            `json:"chain_id"`
 	ChainName     string         `json:"chain_name"`
 	Balances      []TokenBalance `json:"balances"`
@@ -71,11 +72,11 @@ type BalanceResponse struct {
 
 // AccountAddress represents an account address information
 type AccountAddress struct {
-	AccountID        string `json:"account_id"`
-	TokenID          string `json:"token_id"`
-	RecipientAddress string `json:"recipient_address"`
-	RefName          string `json:"ref_name"`
-	CreatedAt        string `json:"created_at"`
+	AccountID        string  `json:"account_id"`
+	TokenID          string  `json:"token_id"`
+	RecipientAddress Address `json:"recipient_address"`
+	RefName          string  `json:"ref_name"`
+	CreatedAt        string  `json:"created_at"`
 }
 
 // GetAccountInfo retrieves account information for the authenticated user
@@ -228,12 +229,22 @@ func (c *Client) UpdateAccountInfo(companyName, companyURL string) (*UpdateAccou
 
 // GetTokenBalances queries token balances for a wallet address on specified chain
 func (c *Client) GetTokenBalances(walletAddress string, chainID int, tokenSymbol string) (*BalanceResponse, error) {
+	return c.getTokenBalances(context.Background(), walletAddress, chainID, tokenSymbol)
+}
+
+func (c *Client) getTokenBalances(ctx context.Context, walletAddress string, chainID int, tokenSymbol string) (*BalanceResponse, error) {
 	// 构建请求
 	reqBody := BalanceRequest{
-		WalletAddress: walletAddress,
+		WalletAddress: Address(walletAddress),
 		ChainID:       chainID,
 		TokenSymbol:   tokenSymbol,
 	}
+	if !c.skipValidation {
+		if err := reqBody.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	reqBody.WalletAddress = reqBody.WalletAddress.Checksum()
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -242,7 +253,7 @@ func (c *Client) GetTokenBalances(walletAddress string, chainID int, tokenSymbol
 
 	// 创建 HTTP 请求
 	url := c.url + "/accounts/wallet/info"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
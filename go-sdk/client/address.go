@@ -0,0 +1,122 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/internal/keccak"
+)
+
+var (
+	// ErrInvalidAddress is returned when an address is not 0x followed by 40 hex characters
+	ErrInvalidAddress = errors.New("invalid EVM address")
+	// ErrAddressChecksum is returned when a mixed-case address fails EIP-55 checksum validation
+	ErrAddressChecksum = errors.New("EVM address checksum mismatch")
+)
+
+// Address represents an EVM address such as a recipient, contract or wallet address.
+// Addresses received from the API are kept as-is; use Validate or ParseAddress
+// before sending user supplied addresses.
+type Address string
+
+// ParseAddress validates s and returns it in EIP-55 checksummed form.
+// All-lowercase and all-uppercase addresses are accepted and normalized,
+// mixed-case addresses must carry a valid checksum.
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 42 || !(strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAddress, s)
+	}
+	body := s[2:]
+	if _, err := hex.DecodeString(body); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAddress, s)
+	}
+
+	checksummed := toChecksum(body)
+	if body != strings.ToLower(body) && body != strings.ToUpper(body) && "0x"+body != checksummed {
+		return "", fmt.Errorf("%w: got %s, expected %s", ErrAddressChecksum, s, checksummed)
+	}
+	return Address(checksummed), nil
+}
+
+// MustParseAddress is like ParseAddress but panics on error
+func MustParseAddress(s string) Address {
+	addr, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// IsValidAddress reports whether s is a well-formed address with a valid checksum
+func IsValidAddress(s string) bool {
+	_, err := ParseAddress(s)
+	return err == nil
+}
+
+// Validate checks that the address is well-formed and correctly checksummed
+func (a Address) Validate() error {
+	_, err := ParseAddress(string(a))
+	return err
+}
+
+// Checksum returns the EIP-55 form of the address, or the address unchanged if it is malformed
+func (a Address) Checksum() Address {
+	addr, err := ParseAddress(string(a))
+	if err != nil {
+		return a
+	}
+	return addr
+}
+
+// Equal reports whether two addresses refer to the same account, ignoring case
+func (a Address) Equal(other Address) bool {
+	return strings.EqualFold(strings.TrimSpace(string(a)), strings.TrimSpace(string(other)))
+}
+
+// IsZero reports whether the address is empty or the zero address
+func (a Address) IsZero() bool {
+	s := strings.TrimSpace(string(a))
+	return s == "" || strings.EqualFold(s, "0x0000000000000000000000000000000000000000")
+}
+
+// Bytes returns the 20 raw address bytes, or nil if the address is malformed
+func (a Address) Bytes() []byte {
+	s := strings.TrimSpace(string(a))
+	if len(s) != 42 || !(strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) {
+		return nil
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (a Address) String() string {
+	return string(a)
+}
+
+// toChecksum applies EIP-55 mixed-case encoding to 40 hex characters
+func toChecksum(body string) string {
+	lower := strings.ToLower(body)
+	hash := keccak.Sum256([]byte(lower))
+
+	out := make([]byte, 0, 42)
+	out = append(out, '0', 'x')
+	for i := 0; i < len(lower); i++ {
+		ch := lower[i]
+		// 哈希对应半字节 >= 8 时大写
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if ch >= 'a' && ch <= 'f' && nibble&0x0f >= 8 {
+			ch -= 'a' - 'A'
+		}
+		out = append(out, ch)
+	}
+	return string(out)
+}
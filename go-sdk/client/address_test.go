@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test vectors from EIP-55
+var eip55Vectors = []string{
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestParseAddressChecksum(t *testing.T) {
+	for _, v := range eip55Vectors {
+		for _, input := range []string{v, strings.ToLower(v), "0x" + strings.ToUpper(v[2:])} {
+			got, err := ParseAddress(input)
+			if err != nil {
+				t.Fatalf("ParseAddress(%s): %v", input, err)
+			}
+			if string(got) != v {
+				t.Errorf("ParseAddress(%s) = %s, want %s", input, got, v)
+			}
+		}
+	}
+}
+
+func TestParseAddressBadChecksum(t *testing.T) {
+	// 翻转一个字母的大小写
+	v := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	bad := v[:4] + "a" + v[5:]
+	if _, err := ParseAddress(bad); !errors.Is(err, ErrAddressChecksum) {
+		t.Fatalf("ParseAddress(%s) = %v, want ErrAddressChecksum", bad, err)
+	}
+}
+
+func TestParseAddressMalformed(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"0x",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"zz5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg",
+	} {
+		if _, err := ParseAddress(input); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("ParseAddress(%q) = %v, want ErrInvalidAddress", input, err)
+		}
+	}
+}
+
+func TestAddressBytes(t *testing.T) {
+	if b := Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed").Bytes(); len(b) != 20 || b[0] != 0x5a || b[19] != 0xed {
+		t.Errorf("Bytes() = %x", b)
+	}
+	if b := Address("zz5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed").Bytes(); b != nil {
+		t.Errorf("Bytes() without 0x prefix = %x, want nil", b)
+	}
+}
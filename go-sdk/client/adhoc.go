@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+)
+
+// AdHocRecipient is one address paid by an ad-hoc payment
+type AdHocRecipient struct {
+	RecipientAddress Address `json:"recipient_address"`
+	Amount           string  `json:"amount"` // wei format
+}
+
+// ExternalCreateAdHocPaymentRequest represents the request for creating a payment without a
+// pre-registered product, such as a custom invoice or a tip. Either pay one RecipientAddress
+// the full Amount, or split the payment between several Recipients.
+type ExternalCreateAdHocPaymentRequest struct {
+	TokenID string `json:"token_id"`
+	// Amount is the amount in the token's smallest unit paid to RecipientAddress
+	Amount           string  `json:"amount,omitempty"`
+	RecipientAddress Address `json:"recipient_address,omitempty"`
+	// Recipients splits the payment between several addresses, instead of Amount and RecipientAddress
+	Recipients  []*AdHocRecipient `json:"recipients,omitempty"`
+	Description string            `json:"description"`
+	PaymentOptions
+}
+
+// SetAmount sets the amount paid to RecipientAddress from a human or base unit Amount
+func (r *ExternalCreateAdHocPaymentRequest) SetAmount(a Amount) {
+	r.Amount = a.BaseUnits()
+}
+
+// TotalAmount returns the sum paid to all recipients in base units, or an error if an amount is invalid
+func (r *ExternalCreateAdHocPaymentRequest) TotalAmount() (*big.Int, error) {
+	if len(r.Recipients) == 0 {
+		v, ok := new(big.Int).SetString(r.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q", r.Amount)
+		}
+		return v, nil
+	}
+	total := new(big.Int)
+	for _, rc := range r.Recipients {
+		if rc == nil {
+			return nil, fmt.Errorf("nil recipient")
+		}
+		v, ok := new(big.Int).SetString(rc.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q for %s", rc.Amount, rc.RecipientAddress)
+		}
+		total.Add(total, v)
+	}
+	return total, nil
+}
+
+// Validate checks the request before it is sent to the server
+func (r *ExternalCreateAdHocPaymentRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("token_id", r.TokenID)
+	if len(r.Recipients) == 0 {
+		fe.baseUnits("amount", r.Amount)
+		fe.address("recipient_address", r.RecipientAddress)
+	} else {
+		if r.Amount != "" || r.RecipientAddress != "" {
+			fe.add("recipients", "cannot be combined with amount and recipient_address")
+		}
+		for i, rc := range r.Recipients {
+			field := fmt.Sprintf("recipients[%d]", i)
+			if rc == nil {
+				fe.add(field, "is required")
+				continue
+			}
+			fe.address(field+".recipient_address", rc.RecipientAddress)
+			fe.baseUnits(field+".amount", rc.Amount)
+		}
+	}
+	fe.required("description", r.Description)
+	r.PaymentOptions.validate(&fe)
+	return fe.err("ExternalCreateAdHocPaymentRequest")
+}
+
+// ExternalCreateAdHocPayment creates a payment for an arbitrary amount without a product.
+// The response has the same shape as ExternalCreatePayment, including the PayLink.
+func (c *Client) ExternalCreateAdHocPayment(ctx context.Context, req *ExternalCreateAdHocPaymentRequest) (*ExternalCreatePaymentResponse, error) {
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c.priceGuard != nil {
+		total, err := req.TotalAmount()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkPrice(total.String(), req.TokenID); err != nil {
+			return nil, err
+		}
+	}
+
+	normalized := *req
+	if req.RecipientAddress != "" {
+		normalized.RecipientAddress = req.RecipientAddress.Checksum()
+	}
+	if len(req.Recipients) > 0 {
+		normalized.Recipients = make([]*AdHocRecipient, len(req.Recipients))
+		for i, rc := range req.Recipients {
+			if rc == nil {
+				continue
+			}
+			normalized.Recipients[i] = &AdHocRecipient{RecipientAddress: rc.RecipientAddress.Checksum(), Amount: rc.Amount}
+		}
+	}
+	reqBody, err := json.Marshal(&normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	url := c.url + "/external/payments/adhoc"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.tokenHolder != nil {
+		httpReq.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var response ExternalCreatePaymentResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount is a lossless token amount held in the token's smallest unit together
+// with the token's decimals, so that it can be shown in human units without
+// going through floating point.
+type Amount struct {
+	Value    *big.Int
+	Decimals int
+}
+
+// NewAmount returns an Amount for a value already expressed in base units
+func NewAmount(value *big.Int, decimals int) Amount {
+	if value == nil {
+		value = new(big.Int)
+	}
+	return Amount{Value: new(big.Int).Set(value), Decimals: decimals}
+}
+
+// ParseBaseUnits parses an integer amount in the token's smallest unit, such as a wei string
+func ParseBaseUnits(s string, decimals int) (Amount, error) {
+	v, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid base unit amount %q", s)
+	}
+	return Amount{Value: v, Decimals: decimals}, nil
+}
+
+// ParseUnits parses a human readable amount such as "1.5" into base units.
+// It fails if the amount has no digits or more fractional digits than the token supports.
+func ParseUnits(s string, decimals int) (Amount, error) {
+	s = strings.TrimSpace(s)
+	input := s
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return Amount{}, fmt.Errorf("invalid amount %q: no digits", input)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return Amount{}, fmt.Errorf("amount %q has more than %d decimal places", s, decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	v, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if neg {
+		v.Neg(v)
+	}
+	return Amount{Value: v, Decimals: decimals}, nil
+}
+
+// BaseUnits returns the amount as an integer string in the token's smallest unit
+func (a Amount) BaseUnits() string {
+	if a.Value == nil {
+		return "0"
+	}
+	return a.Value.String()
+}
+
+// String returns the amount in human units with trailing zeros removed
+func (a Amount) String() string {
+	if a.Value == nil {
+		return "0"
+	}
+	if a.Decimals <= 0 {
+		return a.Value.String()
+	}
+	abs := new(big.Int).Abs(a.Value).String()
+	if len(abs) <= a.Decimals {
+		abs = strings.Repeat("0", a.Decimals-len(abs)+1) + abs
+	}
+	whole, frac := abs[:len(abs)-a.Decimals], strings.TrimRight(abs[len(abs)-a.Decimals:], "0")
+	out := whole
+	if frac != "" {
+		out += "." + frac
+	}
+	if a.Value.Sign() < 0 {
+		out = "-" + out
+	}
+	return out
+}
+
+// Rat returns the amount in human units as an exact rational number
+func (a Amount) Rat() *big.Rat {
+	if a.Value == nil {
+		return new(big.Rat)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.Decimals)), nil)
+	return new(big.Rat).SetFrac(a.Value, scale)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount
+func (a Amount) Sign() int {
+	if a.Value == nil {
+		return 0
+	}
+	return a.Value.Sign()
+}
+
+// scaled returns the value expressed with the given number of decimals, which must not be less than a.Decimals
+func (a Amount) scaled(decimals int) *big.Int {
+	v := new(big.Int)
+	if a.Value != nil {
+		v.Set(a.Value)
+	}
+	if decimals > a.Decimals {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-a.Decimals)), nil))
+	}
+	return v
+}
+
+// Add returns a + b. Amounts with different decimals are added at the larger precision.
+func (a Amount) Add(b Amount) Amount {
+	d := max(a.Decimals, b.Decimals)
+	return Amount{Value: new(big.Int).Add(a.scaled(d), b.scaled(d)), Decimals: d}
+}
+
+// Sub returns a - b. Amounts with different decimals are subtracted at the larger precision.
+func (a Amount) Sub(b Amount) Amount {
+	d := max(a.Decimals, b.Decimals)
+	return Amount{Value: new(big.Int).Sub(a.scaled(d), b.scaled(d)), Decimals: d}
+}
+
+// Cmp compares a and b by value and returns -1, 0 or +1
+func (a Amount) Cmp(b Amount) int {
+	d := max(a.Decimals, b.Decimals)
+	return a.scaled(d).Cmp(b.scaled(d))
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of payments created in parallel by ExternalCreatePayments
+const DefaultBatchConcurrency = 4
+
+// BatchRollbackReason is the close reason used when an all-or-nothing batch is rolled back
+const BatchRollbackReason = "batch rolled back"
+
+// BatchPaymentOptions configures ExternalCreatePayments
+type BatchPaymentOptions struct {
+	// Concurrency is the maximum number of payments created at once
+	Concurrency int
+	// AllOrNothing closes every created payment if any item fails
+	AllOrNothing bool
+}
+
+// BatchPaymentItem is the outcome of one request in a batch
+type BatchPaymentItem struct {
+	Index    int
+	Request  *ExternalCreatePaymentRequest
+	Response *ExternalCreatePaymentResponse
+	Err      error
+	// RolledBack is set when the payment was created and then closed by an all-or-nothing rollback
+	RolledBack bool
+	// RollbackErr is set when closing the payment during rollback failed
+	RollbackErr error
+}
+
+// BatchPaymentResult holds the outcome of every request in a batch, in request order
+type BatchPaymentResult struct {
+	Items []*BatchPaymentItem
+}
+
+// Succeeded returns the items whose payment was created and not rolled back.
+// After a failed rollback this includes the payments that could not be closed.
+func (r *BatchPaymentResult) Succeeded() []*BatchPaymentItem {
+	var items []*BatchPaymentItem
+	for _, it := range r.Items {
+		if it.Err == nil && !it.RolledBack {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// Failed returns the items whose payment could not be created
+func (r *BatchPaymentResult) Failed() []*BatchPaymentItem {
+	var items []*BatchPaymentItem
+	for _, it := range r.Items {
+		if it.Err != nil {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// BatchError is returned by ExternalCreatePayments when at least one item failed
+type BatchError struct {
+	Failed []*BatchPaymentItem
+	// RollbackFailed lists the created payments that could not be closed during rollback
+	// and are still live, for example because the customer already paid
+	RollbackFailed []*BatchPaymentItem
+	// RolledBack is set only if every created payment was closed
+	RolledBack bool
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Failed)+len(e.RollbackFailed))
+	for _, it := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("[%d] %v", it.Index, it.Err))
+	}
+	for _, it := range e.RollbackFailed {
+		msgs = append(msgs, fmt.Sprintf("[%d] rollback of payment %s: %v", it.Index, it.Response.PaymentID, it.RollbackErr))
+	}
+	suffix := ""
+	switch {
+	case e.RolledBack:
+		suffix = ", batch rolled back"
+	case len(e.RollbackFailed) > 0:
+		suffix = fmt.Sprintf(", rollback failed for %d payment(s)", len(e.RollbackFailed))
+	}
+	return fmt.Sprintf("%d payment(s) failed%s: %s", len(e.Failed), suffix, strings.Join(msgs, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed)+len(e.RollbackFailed))
+	for _, it := range e.Failed {
+		errs = append(errs, it.Err)
+	}
+	for _, it := range e.RollbackFailed {
+		errs = append(errs, it.RollbackErr)
+	}
+	return errs
+}
+
+// ExternalCreatePayments creates several payments concurrently, for example one per seller in a
+// marketplace checkout. The result always lists every item in request order. If any item fails a
+// *BatchError is returned as well; with AllOrNothing the payments that were created are then closed.
+// Cancelling ctx aborts the creates in flight. A create aborted after the server received it may
+// still have created a payment, which is then not in the result; look it up by MerchantReference.
+func (c *Client) ExternalCreatePayments(ctx context.Context, reqs []*ExternalCreatePaymentRequest, opts *BatchPaymentOptions) (*BatchPaymentResult, error) {
+	o := BatchPaymentOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultBatchConcurrency
+	}
+
+	result := &BatchPaymentResult{Items: make([]*BatchPaymentItem, len(reqs))}
+	for i, req := range reqs {
+		result.Items[i] = &BatchPaymentItem{Index: i, Request: req}
+	}
+
+	// 先整体校验，避免部分创建后再回滚
+	invalid := false
+	for _, it := range result.Items {
+		switch {
+		case it.Request == nil:
+			it.Err = errors.New("nil request")
+		case !c.skipValidation:
+			it.Err = it.Request.Validate()
+		}
+		invalid = invalid || it.Err != nil
+	}
+	if invalid && o.AllOrNothing {
+		return result, &BatchError{Failed: result.Failed()}
+	}
+
+	sem := make(chan struct{}, o.Concurrency)
+	var wg sync.WaitGroup
+	for _, it := range result.Items {
+		if it.Err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(it *BatchPaymentItem) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				it.Err = ctx.Err()
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				it.Err = err
+				return
+			}
+			it.Response, it.Err = c.externalCreatePayment(ctx, it.Request)
+		}(it)
+	}
+	wg.Wait()
+
+	failed := result.Failed()
+	if len(failed) == 0 {
+		return result, nil
+	}
+	if !o.AllOrNothing {
+		return result, &BatchError{Failed: failed}
+	}
+
+	// 回滚：关闭已创建的支付，即使 ctx 已取消也要执行
+	rollbackCtx := context.WithoutCancel(ctx)
+	for _, it := range result.Items {
+		if it.Err != nil || it.Response == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(it *BatchPaymentItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			_, err := c.ClosePayment(rollbackCtx, it.Response.PaymentID, BatchRollbackReason)
+			if err != nil && !errors.Is(err, ErrPaymentAlreadyClosed) {
+				it.RollbackErr = err
+				return
+			}
+			it.RolledBack = true
+		}(it)
+	}
+	wg.Wait()
+
+	batchErr := &BatchError{Failed: failed}
+	for _, it := range result.Items {
+		if it.RollbackErr != nil {
+			batchErr.RollbackFailed = append(batchErr.RollbackFailed, it)
+		}
+	}
+	batchErr.RolledBack = len(batchErr.RollbackFailed) == 0
+	return result, batchErr
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrChainNotFound is returned by chain lookups that match no supported chain
+var ErrChainNotFound = errors.New("chain not found")
+
+// Chain represents a supported blockchain and the tokens available on it
+type Chain struct {
+	ID          int      `json:"chain_id"`
+	Name        string   `json:"chain_name"`
+	Symbol      string   `json:"chain_symbol"`
+	ExplorerURL string   `json:"explorer_url"`
+	Tokens      []*Token `json:"tokens"`
+}
+
+// ActiveTokens returns the chain's tokens that are currently active
+func (ch *Chain) ActiveTokens() []*Token {
+	var active []*Token
+	for _, t := range ch.Tokens {
+		if t.IsActive {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// ParseChainID normalizes the chain ID representations used across the API.
+// It accepts decimal ("1"), hex ("0x1") and CAIP-2 ("eip155:1") forms.
+func ParseChainID(s string) (int, error) {
+	v := strings.TrimSpace(s)
+	v = strings.TrimPrefix(v, "eip155:")
+	var (
+		id  int64
+		err error
+	)
+	if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+		id, err = strconv.ParseInt(v[2:], 16, 64)
+	} else {
+		id, err = strconv.ParseInt(v, 10, 64)
+	}
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid chain id %q", s)
+	}
+	return int(id), nil
+}
+
+// NumericChainID returns ChainID as an integer, matching Token.ChainID
+func (pt *ProductToken) NumericChainID() (int, error) {
+	return ParseChainID(pt.ChainID)
+}
+
+// ChainRegistry groups the tokens of a TokenRegistry by chain.
+// It shares the token registry's cache and is safe for concurrent use.
+type ChainRegistry struct {
+	tokens *TokenRegistry
+}
+
+// NewChainRegistry creates a chain registry on top of a token registry
+func NewChainRegistry(tokens *TokenRegistry) *ChainRegistry {
+	return &ChainRegistry{tokens: tokens}
+}
+
+// Chains returns the client's chain registry
+func (c *Client) Chains() *ChainRegistry {
+	return c.chainRegistry
+}
+
+func (r *ChainRegistry) build(activeOnly bool) ([]*Chain, error) {
+	tokens, _, err := r.tokens.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*Chain)
+	for _, t := range tokens {
+		if activeOnly && !t.IsActive {
+			continue
+		}
+		ch, ok := byID[t.ChainID]
+		if !ok {
+			ch = &Chain{
+				ID:          t.ChainID,
+				Name:        t.ChainName,
+				Symbol:      t.ChainSymbol,
+				ExplorerURL: t.ExplorerURL,
+			}
+			byID[t.ChainID] = ch
+		}
+		// 部分代币可能缺少链信息，用其他代币补齐
+		if ch.ExplorerURL == "" {
+			ch.ExplorerURL = t.ExplorerURL
+		}
+		if ch.Symbol == "" {
+			ch.Symbol = t.ChainSymbol
+		}
+		ch.Tokens = append(ch.Tokens, t)
+	}
+
+	chains := make([]*Chain, 0, len(byID))
+	for _, ch := range byID {
+		sort.Slice(ch.Tokens, func(i, j int) bool { return ch.Tokens[i].Symbol < ch.Tokens[j].Symbol })
+		chains = append(chains, ch)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+	return chains, nil
+}
+
+// List returns every supported chain with all of its tokens, ordered by chain ID
+func (r *ChainRegistry) List() ([]*Chain, error) {
+	return r.build(false)
+}
+
+// ListActive returns the chains that have at least one active token, with only their active tokens
+func (r *ChainRegistry) ListActive() ([]*Chain, error) {
+	return r.build(true)
+}
+
+// ByID returns the chain with the given chain ID
+func (r *ChainRegistry) ByID(chainID int) (*Chain, error) {
+	chains, err := r.build(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chains {
+		if ch.ID == chainID {
+			return ch, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrChainNotFound, chainID)
+}
+
+// ByName returns the chain with the given name, matched case-insensitively
+func (r *ChainRegistry) ByName(name string) (*Chain, error) {
+	chains, err := r.build(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chains {
+		if strings.EqualFold(ch.Name, name) {
+			return ch, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrChainNotFound, name)
+}
+
+// ForToken returns the chain a token ID belongs to
+func (r *ChainRegistry) ForToken(tokenID string) (*Chain, error) {
+	t, err := r.tokens.ByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ByID(t.ChainID)
+}
+
+// ForProductToken returns the chain of a product token, normalizing its string chain ID
+func (r *ChainRegistry) ForProductToken(pt *ProductToken) (*Chain, error) {
+	id, err := pt.NumericChainID()
+	if err != nil {
+		return r.ForToken(pt.TokenID)
+	}
+	return r.ByID(id)
+}
@@ -14,9 +14,29 @@ type Client struct {
 	url         string
 	apiKey      string
 	tokenHolder *clientToken
+
+	skipValidation bool
+	priceGuard     *PriceGuard
+	tokenCacheTTL  time.Duration
+	tokenRegistry  *TokenRegistry
+	chainRegistry  *ChainRegistry
+
+	currencyKey      CurrencyKeyFunc
+	currencyRegistry *CurrencyRegistry
 }
 
-func NewSDKClient(par context.Context, url string, apiKey string) (*Client, error) {
+// Option configures optional client behaviour
+type Option func(*Client)
+
+// WithSkipValidation disables the client-side request validation that runs before mutating calls.
+// Product recipient addresses are still checked with ParseAddress.
+func WithSkipValidation() Option {
+	return func(c *Client) {
+		c.skipValidation = true
+	}
+}
+
+func NewSDKClient(par context.Context, url string, apiKey string, opts ...Option) (*Client, error) {
 	ctx, cancel := context.WithCancel(par)
 	c := &Client{
 		ctx:    ctx,
@@ -24,6 +44,12 @@ func NewSDKClient(par context.Context, url string, apiKey string) (*Client, erro
 		url:    url,
 		apiKey: apiKey,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.tokenRegistry = NewTokenRegistry(ctx, c.ListTokens, c.tokenCacheTTL)
+	c.chainRegistry = NewChainRegistry(c.tokenRegistry)
+	c.currencyRegistry = NewCurrencyRegistry(c.tokenRegistry, c.currencyKey)
 	resp, err := c.loginByAPIKey(apiKey)
 	if err != nil {
 		return nil, err
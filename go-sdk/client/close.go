@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrPaymentAlreadyPaid is returned when closing a payment that has already been paid
+	ErrPaymentAlreadyPaid = errors.New("payment already paid")
+	// ErrPaymentAlreadyClosed is returned when closing a payment that is already closed or expired
+	ErrPaymentAlreadyClosed = errors.New("payment already closed")
+	// ErrPaymentNotPaid is returned when refunding a payment that has not been paid
+	ErrPaymentNotPaid = errors.New("payment not paid")
+)
+
+// PaymentStateError is returned when an operation is not allowed in the payment's current status.
+// It matches ErrPaymentAlreadyPaid, ErrPaymentAlreadyClosed or ErrPaymentNotPaid with errors.Is.
+type PaymentStateError struct {
+	PaymentID string
+	Status    string
+	Message   string
+}
+
+func (e *PaymentStateError) Error() string {
+	return fmt.Sprintf("payment %s is %s: %s", e.PaymentID, e.Status, e.Message)
+}
+
+func (e *PaymentStateError) Is(target error) bool {
+	switch target {
+	case ErrPaymentAlreadyPaid:
+		return e.Status == PaymentStatusPaid
+	case ErrPaymentAlreadyClosed:
+		return e.Status == PaymentStatusClosed || e.Status == PaymentStatusExpired
+	case ErrPaymentNotPaid:
+		return e.Status != PaymentStatusPaid
+	}
+	return false
+}
+
+// ClosePaymentRequest represents the request for closing a payment
+type ClosePaymentRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClosePaymentResponse represents the response for closing a payment
+type ClosePaymentResponse struct {
+	Message string   `json:"message"`
+	Status  string   `json:"status,omitempty"` // current payment status when the close is rejected
+	Payment *Payment `json:"payment"`
+}
+
+// ClosePayment closes a pending payment so that its pay link can no longer be used.
+// Closing a paid payment fails with ErrPaymentAlreadyPaid, closing a closed or expired
+// payment fails with ErrPaymentAlreadyClosed.
+func (c *Client) ClosePayment(ctx context.Context, paymentID string, reason string) (*Payment, error) {
+	reqBody, err := json.Marshal(&ClosePaymentRequest{Reason: reason})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	url := c.url + "/external/payments/" + paymentID + "/close"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.tokenHolder != nil {
+		httpReq.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var response ClosePaymentResponse
+	if resp.StatusCode == http.StatusConflict {
+		// 支付状态不允许关闭
+		if err := json.Unmarshal(body, &response); err == nil {
+			status := response.Status
+			if status == "" && response.Payment != nil {
+				status = response.Payment.Status
+			}
+			if status != "" {
+				return nil, &PaymentStateError{PaymentID: paymentID, Status: status, Message: response.Message}
+			}
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Payment == nil {
+		return nil, fmt.Errorf("API response missing payment")
+	}
+	return response.Payment, nil
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrCurrencyNotFound is returned by currency lookups that match no token
+var ErrCurrencyNotFound = errors.New("currency not found")
+
+// CurrencyKeyFunc returns the currency a token belongs to. Tokens with the same key are treated as equivalent.
+type CurrencyKeyFunc func(t *Token) string
+
+// DefaultCurrencyKey groups tokens by their upper-cased symbol, so USDC on every chain is one currency
+func DefaultCurrencyKey(t *Token) string {
+	return strings.ToUpper(strings.TrimSpace(t.Symbol))
+}
+
+// WithCurrencyKey overrides how tokens are grouped into currencies
+func WithCurrencyKey(fn CurrencyKeyFunc) Option {
+	return func(c *Client) {
+		c.currencyKey = fn
+	}
+}
+
+// Currency groups equivalent tokens across chains, such as USDC on Ethereum and on Base.
+// Amounts of its tokens are normalized to Decimals, the largest precision among them,
+// so that they can be added without loss.
+type Currency struct {
+	Code     string
+	Type     string // Token.CurrencyType of the grouped tokens
+	Decimals int
+	Tokens   []*Token
+}
+
+// Token returns the currency's token on the given chain
+func (cur *Currency) Token(chainID int) (*Token, bool) {
+	for _, t := range cur.Tokens {
+		if t.ChainID == chainID {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Normalize converts an amount of one of the currency's tokens to the currency's precision
+func (cur *Currency) Normalize(amount Amount) Amount {
+	if amount.Decimals >= cur.Decimals {
+		return amount
+	}
+	return Amount{Value: amount.scaled(cur.Decimals), Decimals: cur.Decimals}
+}
+
+// CurrencyRegistry groups the tokens of a TokenRegistry into currencies.
+// It shares the token registry's cache and is safe for concurrent use.
+type CurrencyRegistry struct {
+	tokens *TokenRegistry
+	key    CurrencyKeyFunc
+}
+
+// NewCurrencyRegistry creates a currency registry on top of a token registry. A nil key uses DefaultCurrencyKey.
+func NewCurrencyRegistry(tokens *TokenRegistry, key CurrencyKeyFunc) *CurrencyRegistry {
+	if key == nil {
+		key = DefaultCurrencyKey
+	}
+	return &CurrencyRegistry{tokens: tokens, key: key}
+}
+
+// Currencies returns the client's currency registry
+func (c *Client) Currencies() *CurrencyRegistry {
+	return c.currencyRegistry
+}
+
+func (r *CurrencyRegistry) build(activeOnly bool) (map[string]*Currency, error) {
+	tokens, _, err := r.tokens.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[string]*Currency)
+	for _, t := range tokens {
+		if activeOnly && !t.IsActive {
+			continue
+		}
+		code := r.key(t)
+		cur, ok := currencies[code]
+		if !ok {
+			cur = &Currency{Code: code, Type: t.CurrencyType}
+			currencies[code] = cur
+		}
+		cur.Decimals = max(cur.Decimals, t.Decimals)
+		cur.Tokens = append(cur.Tokens, t)
+	}
+	for _, cur := range currencies {
+		sort.Slice(cur.Tokens, func(i, j int) bool { return cur.Tokens[i].ChainID < cur.Tokens[j].ChainID })
+	}
+	return currencies, nil
+}
+
+// List returns every currency, ordered by code
+func (r *CurrencyRegistry) List() ([]*Currency, error) {
+	currencies, err := r.build(false)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Currency, 0, len(currencies))
+	for _, cur := range currencies {
+		list = append(list, cur)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list, nil
+}
+
+// Get returns a currency by code, e.g. "USDC", including inactive tokens
+func (r *CurrencyRegistry) Get(code string) (*Currency, error) {
+	return r.get(code, false)
+}
+
+// GetActive returns a currency by code with only its active tokens
+func (r *CurrencyRegistry) GetActive(code string) (*Currency, error) {
+	return r.get(code, true)
+}
+
+func (r *CurrencyRegistry) get(code string, activeOnly bool) (*Currency, error) {
+	currencies, err := r.build(activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	cur, ok := currencies[code]
+	if !ok {
+		cur, ok = currencies[strings.ToUpper(strings.TrimSpace(code))]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCurrencyNotFound, code)
+	}
+	return cur, nil
+}
+
+// ForToken returns the currency a token ID belongs to
+func (r *CurrencyRegistry) ForToken(tokenID string) (*Currency, error) {
+	t, err := r.tokens.ByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(r.key(t))
+}
+
+// CurrencyTotals maps a currency code to an amount normalized to the currency's precision
+type CurrencyTotals map[string]Amount
+
+func (ct CurrencyTotals) add(cur *Currency, amount Amount) {
+	ct[cur.Code] = addAmount(ct, cur.Code, cur.Normalize(amount))
+}
+
+// AggregateBalances sums token balances per currency across chains
+func (r *CurrencyRegistry) AggregateBalances(balances []TokenBalance) (CurrencyTotals, error) {
+	currencies, err := r.build(false)
+	if err != nil {
+		return nil, err
+	}
+	totals := make(CurrencyTotals)
+	for i := range balances {
+		b := &balances[i]
+		amount, err := b.Amount()
+		if err != nil {
+			return nil, fmt.Errorf("token %s: %w", b.Symbol, err)
+		}
+		// 余额中的代币未必在注册表里，按符号兜底
+		var cur *Currency
+		if t, err := r.tokens.ByID(b.TokenID); err == nil {
+			cur = currencies[r.key(t)]
+		}
+		if cur == nil {
+			cur = &Currency{Code: DefaultCurrencyKey(&Token{Symbol: b.Symbol}), Decimals: b.Decimals}
+			if known, ok := currencies[cur.Code]; ok {
+				cur = known
+			}
+		}
+		totals.add(cur, amount)
+	}
+	return totals, nil
+}
+
+// PortfolioByCurrency sums a portfolio's balances per currency across chains
+func (r *CurrencyRegistry) PortfolioByCurrency(p *Portfolio) (CurrencyTotals, error) {
+	var balances []TokenBalance
+	for _, ch := range p.Chains {
+		balances = append(balances, ch.Balances...)
+	}
+	return r.AggregateBalances(balances)
+}
+
+// AggregateSales sums the TotalAmount of paid payments per currency. Sales are summed from
+// payments because the unit of ProductTokenStatus.TotalSaleAmount is not specified by the API.
+func (r *CurrencyRegistry) AggregateSales(payments []*Payment) (CurrencyTotals, error) {
+	totals := make(CurrencyTotals)
+	for _, p := range payments {
+		if p == nil || p.Status != PaymentStatusPaid {
+			continue
+		}
+		t, err := r.tokens.ByID(p.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("payment %s: %w", p.PaymentID, err)
+		}
+		cur, err := r.ForToken(p.TokenID)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := ParseBaseUnits(p.TotalAmount, t.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("payment %s: %w", p.PaymentID, err)
+		}
+		totals.add(cur, amount)
+	}
+	return totals, nil
+}
+
+// GetProductSalesByCurrency returns the paid payments of a product summed per currency
+func (c *Client) GetProductSalesByCurrency(productID string) (CurrencyTotals, error) {
+	payments, err := c.ListPaymentsByAccountAndProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	return c.currencyRegistry.AggregateSales(payments.Payments)
+}
+
+// CreateCurrencyProductRequest represents the request for creating a product priced in one currency on several chains
+type CreateCurrencyProductRequest struct {
+	Name             string
+	Description      string
+	Content          string
+	Currency         string // currency code, e.g. "USDC"
+	Price            string // price in human units, e.g. "19.99"
+	RecipientAddress Address
+	// ChainIDs limits the product to these chains; every chain with an active token of the currency is used if empty
+	ChainIDs []int
+}
+
+// CreateProductInCurrency creates a product payable with the given currency on every supported chain.
+// The human price is converted to base units with each token's decimals. Tokens that share the
+// first token's decimals are created with the product, the rest are added with AddProductToken.
+// If adding a token fails, the partially created product is returned together with the error.
+func (c *Client) CreateProductInCurrency(req *CreateCurrencyProductRequest) (*CreateProductResponse, error) {
+	cur, err := c.currencyRegistry.GetActive(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	tokens := cur.Tokens
+	if len(req.ChainIDs) > 0 {
+		tokens = nil
+		for _, id := range req.ChainIDs {
+			t, ok := cur.Token(id)
+			if !ok {
+				return nil, fmt.Errorf("%s is not available on chain %d", cur.Code, id)
+			}
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s has no active tokens", cur.Code)
+	}
+
+	// 按精度分组，同精度的代币价格相同
+	prices := make(map[string]string, len(tokens))
+	for _, t := range tokens {
+		price, err := ParseUnits(req.Price, t.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price for %s on chain %d: %w", t.Symbol, t.ChainID, err)
+		}
+		prices[t.TokenID] = price.BaseUnits()
+	}
+	first := prices[tokens[0].TokenID]
+	var initial, rest []*Token
+	for _, t := range tokens {
+		if prices[t.TokenID] == first {
+			initial = append(initial, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+
+	createReq := &CreateProductRequest{
+		Name:             req.Name,
+		Description:      req.Description,
+		Content:          req.Content,
+		Price:            first,
+		RecipientAddress: req.RecipientAddress,
+	}
+	for _, t := range initial {
+		createReq.TokenIDList = append(createReq.TokenIDList, t.TokenID)
+	}
+	resp, err := c.CreateProduct(createReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Product == nil {
+		return nil, fmt.Errorf("API response missing product")
+	}
+
+	for _, t := range rest {
+		added, err := c.AddProductToken(resp.Product.ProductID, &AddProductTokenRequest{
+			TokenID:          t.TokenID,
+			Price:            prices[t.TokenID],
+			RecipientAddress: req.RecipientAddress,
+		})
+		if err != nil {
+			return resp, fmt.Errorf("product %s created, but adding %s on chain %d failed: %w", resp.Product.ProductID, t.Symbol, t.ChainID, err)
+		}
+		if added.ProductToken != nil {
+			resp.Product.ProductTokens = append(resp.Product.ProductTokens, added.ProductToken)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"time"
+)
+
+// SetTTL makes the payment payable for d from creation. d is rounded up to whole seconds,
+// so a positive d never becomes 0, which would mean the server default.
+func (o *PaymentOptions) SetTTL(d time.Duration) {
+	o.ExpiresIn = ttlSeconds(d)
+	o.ExpiresAt = ""
+}
+
+// SetExpiresAt makes the payment payable until t
+func (o *PaymentOptions) SetExpiresAt(t time.Time) {
+	o.ExpiresAt = t.UTC().Format(time.RFC3339)
+	o.ExpiresIn = 0
+}
+
+// ttlSeconds converts d to ExpiresIn, rounding up to whole seconds
+func ttlSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return int64(d / time.Second)
+	}
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+func parseExpiry(expiresAt string) (time.Time, bool) {
+	if expiresAt == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, expiresAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Expiry returns when the payment stops being payable, if the server reported it
+func (p *Payment) Expiry() (time.Time, bool) {
+	return parseExpiry(p.ExpiresAt)
+}
+
+// IsExpired reports whether the payment can no longer be paid at time now.
+// A payment that is already paid or closed is never reported as expired.
+func (p *Payment) IsExpired(now time.Time) bool {
+	switch p.Status {
+	case PaymentStatusExpired:
+		return true
+	case PaymentStatusPaid, PaymentStatusClosed:
+		return false
+	}
+	t, ok := p.Expiry()
+	return ok && !now.Before(t)
+}
+
+// ExpiresIn returns the time left until the payment expires at time now, or false if it has no expiry
+func (p *Payment) ExpiresIn(now time.Time) (time.Duration, bool) {
+	t, ok := p.Expiry()
+	if !ok {
+		return 0, false
+	}
+	return max(t.Sub(now), 0), true
+}
+
+// Expiry returns when the created payment stops being payable, if the server reported it
+func (r *ExternalCreatePaymentResponse) Expiry() (time.Time, bool) {
+	return parseExpiry(r.ExpiresAt)
+}
+
+// IsExpired reports whether the created payment can no longer be paid at time now
+func (r *ExternalCreatePaymentResponse) IsExpired(now time.Time) bool {
+	t, ok := r.Expiry()
+	return ok && !now.Before(t)
+}
+
+func validateExpiry(fe *fieldErrors, expiresIn int64, expiresAt string) {
+	if expiresIn < 0 {
+		fe.add("expires_in", "must not be negative, got %d", expiresIn)
+	}
+	if expiresAt != "" {
+		if expiresIn != 0 {
+			fe.add("expires_at", "cannot be combined with expires_in")
+		}
+		if _, err := time.Parse(time.RFC3339Nano, expiresAt); err != nil {
+			fe.add("expires_at", "must be an RFC 3339 time, got %q", expiresAt)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNoExplorer is returned when a chain has no explorer URL configured
+var ErrNoExplorer = errors.New("no explorer URL configured")
+
+// Explorer link kinds, used as the path segment in explorer URLs
+const (
+	ExplorerTx      = "tx"
+	ExplorerAddress = "address"
+	ExplorerBlock   = "block"
+	ExplorerToken   = "token"
+)
+
+// explorerSegments are path segments that may already be present at the end of an explorer URL
+var explorerSegments = map[string]bool{
+	"tx": true, "txs": true, "transaction": true, "transactions": true,
+	"address": true, "addresses": true, "account": true,
+	"block": true, "blocks": true, "token": true, "tokens": true,
+}
+
+// ExplorerLink builds an explorer URL for the given kind and value. It understands
+// the common explorer URL conventions:
+//
+//   - a plain base URL such as "https://etherscan.io", with or without a trailing slash
+//   - a base URL that already ends in a kind segment such as "https://etherscan.io/tx/"
+//   - query parameters such as "https://explorer.example.com/?network=testnet", which are preserved
+//   - hash routing such as "https://explorer.example.com/#/", where the path goes after the "#"
+//   - templates with {kind} and {value} placeholders, e.g. "https://example.com/{kind}/{value}"
+func ExplorerLink(explorerURL, kind, value string) (string, error) {
+	explorerURL = strings.TrimSpace(explorerURL)
+	if explorerURL == "" {
+		return "", ErrNoExplorer
+	}
+	if strings.Contains(explorerURL, "{value}") {
+		return strings.NewReplacer("{kind}", kind, "{value}", url.PathEscape(value)).Replace(explorerURL), nil
+	}
+
+	u, err := url.Parse(explorerURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid explorer URL %q", explorerURL)
+	}
+	if strings.HasPrefix(u.Fragment, "/") {
+		u.Fragment = joinExplorerPath(u.Fragment, kind, value)
+		return u.String(), nil
+	}
+	u.Path = joinExplorerPath(u.Path, kind, value)
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+func joinExplorerPath(base, kind, value string) string {
+	base = strings.TrimRight(base, "/")
+	if i := strings.LastIndex(base, "/"); i >= 0 && explorerSegments[strings.ToLower(base[i+1:])] {
+		base = base[:i]
+	}
+	return base + "/" + kind + "/" + value
+}
+
+// ExplorerTxURL builds the explorer URL for a transaction hash
+func ExplorerTxURL(explorerURL, txHash string) (string, error) {
+	hash, err := normalizeTxHash(txHash)
+	if err != nil {
+		return "", err
+	}
+	return ExplorerLink(explorerURL, ExplorerTx, hash)
+}
+
+// ExplorerAddressURL builds the explorer URL for an account or contract address
+func ExplorerAddressURL(explorerURL string, addr Address) (string, error) {
+	checksummed, err := ParseAddress(string(addr))
+	if err != nil {
+		return "", err
+	}
+	return ExplorerLink(explorerURL, ExplorerAddress, checksummed.String())
+}
+
+// ExplorerBlockURL builds the explorer URL for a block number
+func ExplorerBlockURL(explorerURL string, blockNumber int64) (string, error) {
+	if blockNumber < 0 {
+		return "", fmt.Errorf("invalid block number %d", blockNumber)
+	}
+	return ExplorerLink(explorerURL, ExplorerBlock, strconv.FormatInt(blockNumber, 10))
+}
+
+// ExplorerTokenURL builds the explorer URL for a token contract
+func ExplorerTokenURL(explorerURL string, contract Address) (string, error) {
+	checksummed, err := ParseAddress(string(contract))
+	if err != nil {
+		return "", err
+	}
+	return ExplorerLink(explorerURL, ExplorerToken, checksummed.String())
+}
+
+func normalizeTxHash(txHash string) (string, error) {
+	h := strings.ToLower(strings.TrimSpace(txHash))
+	if !strings.HasPrefix(h, "0x") {
+		h = "0x" + h
+	}
+	if len(h) != 66 {
+		return "", fmt.Errorf("invalid transaction hash %q", txHash)
+	}
+	if _, err := hex.DecodeString(h[2:]); err != nil {
+		return "", fmt.Errorf("invalid transaction hash %q", txHash)
+	}
+	return h, nil
+}
+
+// TxURL builds the explorer URL for a transaction on this chain
+func (ch *Chain) TxURL(txHash string) (string, error) {
+	return ExplorerTxURL(ch.ExplorerURL, txHash)
+}
+
+// AddressURL builds the explorer URL for an address on this chain
+func (ch *Chain) AddressURL(addr Address) (string, error) {
+	return ExplorerAddressURL(ch.ExplorerURL, addr)
+}
+
+// BlockURL builds the explorer URL for a block on this chain
+func (ch *Chain) BlockURL(blockNumber int64) (string, error) {
+	return ExplorerBlockURL(ch.ExplorerURL, blockNumber)
+}
+
+// TxURL builds the explorer URL for a transaction on the token's chain
+func (t *Token) TxURL(txHash string) (string, error) {
+	return ExplorerTxURL(t.ExplorerURL, txHash)
+}
+
+// AddressURL builds the explorer URL for an address on the token's chain
+func (t *Token) AddressURL(addr Address) (string, error) {
+	return ExplorerAddressURL(t.ExplorerURL, addr)
+}
+
+// ContractURL builds the explorer URL for the token contract itself
+func (t *Token) ContractURL() (string, error) {
+	return ExplorerTokenURL(t.ExplorerURL, t.ContractAddress)
+}
+
+// ExplorerTxURL returns the explorer link for the payment's transaction,
+// looking up the chain explorer through the payment's token
+func (p *Payment) ExplorerTxURL(registry *TokenRegistry) (string, error) {
+	if p.TransactionHash == "" {
+		return "", fmt.Errorf("payment %s has no transaction hash", p.PaymentID)
+	}
+	token, err := registry.ByID(p.TokenID)
+	if err != nil {
+		return "", err
+	}
+	return token.TxURL(p.TransactionHash)
+}
+
+// ExplorerBlockURL returns the explorer link for the block that included the payment
+func (p *Payment) ExplorerBlockURL(registry *TokenRegistry) (string, error) {
+	if p.BlockNumber <= 0 {
+		return "", fmt.Errorf("payment %s has no block number", p.PaymentID)
+	}
+	token, err := registry.ByID(p.TokenID)
+	if err != nil {
+		return "", err
+	}
+	return ExplorerBlockURL(token.ExplorerURL, p.BlockNumber)
+}
+
+// ExplorerAddressURL returns the explorer link for an address on the chain of the product token
+func (pt *ProductToken) ExplorerAddressURL(chains *ChainRegistry, addr Address) (string, error) {
+	chain, err := chains.ForProductToken(pt)
+	if err != nil {
+		return "", err
+	}
+	return chain.AddressURL(addr)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// NativeDecimals is the number of decimals of an EVM chain's native currency (wei)
+const NativeDecimals = 18
+
+// GasCost is an amount of native currency spent on gas on one chain
+type GasCost struct {
+	ChainID int
+	Symbol  string
+	Amount  Amount
+}
+
+// String formats the cost with the chain's native symbol, e.g. "0.00042 ETH"
+func (g *GasCost) String() string {
+	if g.Symbol == "" {
+		return g.Amount.String()
+	}
+	return g.Amount.String() + " " + g.Symbol
+}
+
+// GasCost returns GasUsed * GasPrice in native units. Payments that have not been
+// settled on-chain have no gas data and return an error.
+func (p *Payment) GasCost() (Amount, error) {
+	if p.GasUsed <= 0 || strings.TrimSpace(p.GasPrice) == "" {
+		return Amount{}, fmt.Errorf("payment %s has no gas data", p.PaymentID)
+	}
+	price, ok := new(big.Int).SetString(strings.TrimSpace(p.GasPrice), 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("payment %s has invalid gas price %q", p.PaymentID, p.GasPrice)
+	}
+	cost := new(big.Int).Mul(big.NewInt(p.GasUsed), price)
+	return Amount{Value: cost, Decimals: NativeDecimals}, nil
+}
+
+// GasCostOn returns the payment's gas cost together with the chain it was paid on
+func (p *Payment) GasCostOn(registry *TokenRegistry) (*GasCost, error) {
+	amount, err := p.GasCost()
+	if err != nil {
+		return nil, err
+	}
+	token, err := registry.ByID(p.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	return &GasCost{ChainID: token.ChainID, Symbol: token.ChainSymbol, Amount: amount}, nil
+}
+
+// GasReport sums the gas costs of a set of payments. Costs are kept per chain
+// because each chain pays gas in its own native currency.
+type GasReport struct {
+	// ByChain is the total gas cost per chain ID
+	ByChain map[int]*GasCost
+	// ByProduct is the total gas cost per product ID and chain ID
+	ByProduct map[string]map[int]*GasCost
+	// Counted is the number of payments with gas data
+	Counted int
+	// Skipped lists the IDs of payments without gas data
+	Skipped []string
+}
+
+// Chains returns the per-chain totals ordered by chain ID
+func (r *GasReport) Chains() []*GasCost {
+	costs := make([]*GasCost, 0, len(r.ByChain))
+	for _, c := range r.ByChain {
+		costs = append(costs, c)
+	}
+	sort.Slice(costs, func(i, j int) bool { return costs[i].ChainID < costs[j].ChainID })
+	return costs
+}
+
+// SumGasCosts adds up the gas costs of payments, skipping payments that have not been settled
+func SumGasCosts(payments []*Payment, registry *TokenRegistry) (*GasReport, error) {
+	report := &GasReport{
+		ByChain:   make(map[int]*GasCost),
+		ByProduct: make(map[string]map[int]*GasCost),
+	}
+	add := func(totals map[int]*GasCost, cost *GasCost) {
+		if total, ok := totals[cost.ChainID]; ok {
+			total.Amount = total.Amount.Add(cost.Amount)
+			return
+		}
+		totals[cost.ChainID] = &GasCost{ChainID: cost.ChainID, Symbol: cost.Symbol, Amount: NewAmount(cost.Amount.Value, cost.Amount.Decimals)}
+	}
+
+	for _, p := range payments {
+		if p.GasUsed <= 0 || strings.TrimSpace(p.GasPrice) == "" {
+			report.Skipped = append(report.Skipped, p.PaymentID)
+			continue
+		}
+		cost, err := p.GasCostOn(registry)
+		if err != nil {
+			return nil, err
+		}
+		add(report.ByChain, cost)
+		if report.ByProduct[p.ProductID] == nil {
+			report.ByProduct[p.ProductID] = make(map[int]*GasCost)
+		}
+		add(report.ByProduct[p.ProductID], cost)
+		report.Counted++
+	}
+	return report, nil
+}
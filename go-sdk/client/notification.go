@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Email templates for payment success notifications
+const (
+	NotificationTemplateDefault = "default"
+	NotificationTemplateReceipt = "receipt"
+	NotificationTemplateMinimal = "minimal"
+)
+
+// Notification delivery statuses. A notification is pending until the email is
+// handed to the mail server, then ends as delivered, failed or bounced.
+const (
+	NotificationStatusPending   = "pending"
+	NotificationStatusDelivered = "delivered"
+	NotificationStatusFailed    = "failed"
+	NotificationStatusBounced   = "bounced"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist
+var ErrNotificationNotFound = errors.New("notification not found")
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// PaymentNotification is a payment success email set up for one recipient
+type PaymentNotification struct {
+	NotificationID string `json:"notification_id"`
+	PaymentID      string `json:"payment_id"`
+	Email          string `json:"email"`
+	Locale         string `json:"locale,omitempty"`
+	Template       string `json:"template,omitempty"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	FailureReason  string `json:"failure_reason,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+}
+
+// IsDelivered reports whether the email was accepted by the recipient's mail server
+func (n *PaymentNotification) IsDelivered() bool {
+	return n.Status == NotificationStatusDelivered
+}
+
+// IsTerminal reports whether the notification will not be retried
+func (n *PaymentNotification) IsTerminal() bool {
+	return n.Status == NotificationStatusDelivered || n.Status == NotificationStatusFailed || n.Status == NotificationStatusBounced
+}
+
+// ListPaymentNotificationsResponse represents the response for listing the notifications of a payment
+type ListPaymentNotificationsResponse struct {
+	Message       string                 `json:"message"`
+	Notifications []*PaymentNotification `json:"notifications"`
+}
+
+// Recipients returns Email and Emails merged, trimmed and without duplicates
+func (r *ExternalSendNotifyForPaymentSuccessRequest) Recipients() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range append([]string{r.Email}, r.Emails...) {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[strings.ToLower(e)] {
+			continue
+		}
+		seen[strings.ToLower(e)] = true
+		out = append(out, e)
+	}
+	return out
+}
+
+// Validate checks the request before it is sent to the server
+func (r *ExternalSendNotifyForPaymentSuccessRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("payment_id", r.PaymentID)
+	recipients := r.Recipients()
+	if len(recipients) == 0 {
+		fe.add("emails", "at least one recipient is required")
+	}
+	for _, e := range recipients {
+		if addr, err := mail.ParseAddress(e); err != nil || addr.Address != e {
+			fe.add("emails", "invalid email address %q", e)
+		}
+	}
+	if r.Locale != "" && !localePattern.MatchString(r.Locale) {
+		fe.add("locale", "must be a language tag such as \"en\" or \"zh-CN\", got %q", r.Locale)
+	}
+	return fe.err("ExternalSendNotifyForPaymentSuccessRequest")
+}
+
+// ListPaymentNotifications retrieves the success notifications set up for a payment and their delivery status
+func (c *Client) ListPaymentNotifications(ctx context.Context, paymentID string) (*ListPaymentNotificationsResponse, error) {
+	reqURL := c.url + "/payments/" + url.PathEscape(paymentID) + "/notifications"
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.tokenHolder != nil {
+		req.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentNotFound, paymentID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var response ListPaymentNotificationsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
+
+// DeletePaymentNotification removes a success notification so that it is no longer sent
+func (c *Client) DeletePaymentNotification(ctx context.Context, paymentID, notificationID string) error {
+	reqURL := c.url + "/payments/" + url.PathEscape(paymentID) + "/notifications/" + url.PathEscape(notificationID)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.tokenHolder != nil {
+		req.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotificationNotFound, notificationID)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,28 +11,53 @@ import (
 	"strconv"
 )
 
+// Payment statuses
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusPaid    = "paid"
+	PaymentStatusClosed  = "closed"
+	PaymentStatusExpired = "expired"
+)
+
+// IsTerminalPaymentStatus reports whether a payment in this status can no longer change
+func IsTerminalPaymentStatus(status string) bool {
+	switch status {
+	case PaymentStatusPaid, PaymentStatusClosed, PaymentStatusExpired:
+		return true
+	}
+	return false
+}
+
 // Payment represents a payment information
 type Payment struct {
-	PaymentID        string `json:"payment_id"`
-	AccountID        string `json:"account_id"`
-	TokenID          string `json:"token_id"`
-	ProductID        string `json:"product_id"`
-	ProductTokenID   string `json:"product_token_id"`
-	Count            int    `json:"count"`
-	Status           string `json:"status"`
-	PayerEmail       string `json:"payer_email,omitempty"`
-	CreatedAt        string `json:"created_at"`
-	UpdatedAt        string `json:"updated_at"`
-	PaidAt           string `json:"paid_at,omitempty"`
-	ClosedAt         string `json:"closed_at,omitempty"`
-	CloseReason      string `json:"close_reason,omitempty"`
-	TransactionHash  string `json:"transaction_hash,omitempty"`
-	BlockNumber      int64  `json:"block_number,omitempty"`
-	GasUsed          int64  `json:"gas_used,omitempty"`
-	GasPrice         string `json:"gas_price,omitempty"`
-	TotalAmount      string `json:"total_amount"`
-	FeeAmount        string `json:"fee_amount"`
-	RecipientAmount  string `json:"recipient_amount"`
+	PaymentID         string            `json:"payment_id"`
+	AccountID         string            `json:"account_id"`
+	TokenID           string            `json:"token_id"`
+	ProductID         string            `json:"product_id"`
+	ProductTokenID    string            `json:"product_token_id"`
+	Count             int               `json:"count"`
+	Status            string            `json:"status"`
+	PayerEmail        string            `json:"payer_email,omitempty"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
+	PaidAt            string            `json:"paid_at,omitempty"`
+	ClosedAt          string            `json:"closed_at,omitempty"`
+	CloseReason       string            `json:"close_reason,omitempty"`
+	TransactionHash   string            `json:"transaction_hash,omitempty"`
+	BlockNumber       int64             `json:"block_number,omitempty"`
+	GasUsed           int64             `json:"gas_used,omitempty"`
+	GasPrice          string            `json:"gas_price,omitempty"`
+	TotalAmount       string            `json:"total_amount"`
+	FeeAmount         string            `json:"fee_amount"`
+	RecipientAmount   string            `json:"recipient_amount"`
+	ReceivedAmount    string            `json:"received_amount,omitempty"` // amount actually received, wei format
+	PartiallyPaid     bool              `json:"partially_paid,omitempty"`
+	Overpaid          bool              `json:"overpaid,omitempty"`
+	ExpiresAt         string            `json:"expires_at,omitempty"`
+	RefundedAmount    string            `json:"refunded_amount,omitempty"`
+	Refunds           []*Refund         `json:"refunds,omitempty"`
+	MerchantReference string            `json:"merchant_reference,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
 }
 
 // ListPaymentsResponse represents the response for listing payments
@@ -53,12 +79,19 @@ type ListPaymentsResponseWithPagination struct {
 // ExternalSendNotifyForPaymentSuccessRequest represents the request for external payment success notification
 type ExternalSendNotifyForPaymentSuccessRequest struct {
 	PaymentID string `json:"payment_id"`
-	Email     string `json:"email"`
+	Email     string `json:"email,omitempty"`
+	// Emails notifies several recipients; Email is merged into it
+	Emails []string `json:"emails,omitempty"`
+	// Locale is the language of the email such as "en" or "zh-CN"; the account default if empty
+	Locale string `json:"locale,omitempty"`
+	// Template selects the email layout, NotificationTemplateDefault if empty
+	Template string `json:"template,omitempty"`
 }
 
 // SendNotifyForPaymentSuccessResponse represents the response for sending payment success notification
 type SendNotifyForPaymentSuccessResponse struct {
-	Message string `json:"message"`
+	Message       string                 `json:"message"`
+	Notifications []*PaymentNotification `json:"notifications,omitempty"`
 }
 
 // ListPaymentsByAccountAndProductID retrieves all payments for the authenticated account and specific product
@@ -104,6 +137,10 @@ func (c *Client) ListPaymentsByAccountAndProductID(productID string) (*ListPayme
 
 // ListPaymentsByAccount retrieves all payments for the authenticated account with pagination
 func (c *Client) ListPaymentsByAccount(limit, offset int) (*ListPaymentsResponseWithPagination, error) {
+	return c.listPaymentsByAccount(context.Background(), limit, offset)
+}
+
+func (c *Client) listPaymentsByAccount(ctx context.Context, limit, offset int) (*ListPaymentsResponseWithPagination, error) {
 	// 构建 URL 和查询参数
 	baseURL := c.url + "/payments/list"
 	u, err := url.Parse(baseURL)
@@ -122,7 +159,7 @@ func (c *Client) ListPaymentsByAccount(limit, offset int) (*ListPaymentsResponse
 	u.RawQuery = params.Encode()
 
 	// 创建请求
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -162,8 +199,12 @@ func (c *Client) ListPaymentsByAccount(limit, offset int) (*ListPaymentsResponse
 
 // GetPaymentByID retrieves a specific payment by ID
 func (c *Client) GetPaymentByID(paymentID string) (*Payment, error) {
+	return c.getPaymentByID(context.Background(), paymentID)
+}
+
+func (c *Client) getPaymentByID(ctx context.Context, paymentID string) (*Payment, error) {
 	url := c.url + "/payments/" + paymentID
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -201,10 +242,24 @@ func (c *Client) GetPaymentByID(paymentID string) (*Payment, error) {
 	return &payment, nil
 }
 
-// ExternalSendNotifyForPaymentSuccess sets up email notification for payment success
+// ExternalSendNotifyForPaymentSuccess sets up email notifications for payment success, one per recipient
 func (c *Client) ExternalSendNotifyForPaymentSuccess(req *ExternalSendNotifyForPaymentSuccessRequest) (*SendNotifyForPaymentSuccessResponse, error) {
+	// 校验请求
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// 序列化请求体
-	reqBody, err := json.Marshal(req)
+	normalized := *req
+	normalized.Emails = req.Recipients()
+	normalized.Email = ""
+	if len(normalized.Emails) == 1 {
+		// 单个收件人时保留旧字段
+		normalized.Email, normalized.Emails = normalized.Emails[0], nil
+	}
+	reqBody, err := json.Marshal(&normalized)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
@@ -252,18 +307,34 @@ func (c *Client) ExternalSendNotifyForPaymentSuccess(req *ExternalSendNotifyForP
 
 // PaymentReceiver represents a payment receiver information
 type PaymentReceiver struct {
-	Type             string `json:"type"` // "fee" or "merchant"
-	RecipientAddress string `json:"recipient_address"`
-	Amount           string `json:"amount"` // wei format
-	Rate             string `json:"rate"`   // percentage
+	Type             string  `json:"type"` // "fee" or "merchant"
+	RecipientAddress Address `json:"recipient_address"`
+	Amount           string  `json:"amount"` // wei format
+	Rate             string  `json:"rate"`   // percentage
 }
 
+// PaymentOptions are the optional settings shared by every request that creates a payment
+type PaymentOptions struct {
+	// ExpiresIn is how many seconds the payment stays payable; the server default applies if zero
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// ExpiresAt is an absolute RFC 3339 expiry time, mutually exclusive with ExpiresIn
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// MerchantReference is the merchant's own identifier for the payment, such as an order number
+	MerchantReference string `json:"merchant_reference,omitempty"`
+	// Metadata is stored with the payment and returned on Payment
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// SuccessURL is where the customer is sent after paying; {payment_id}, {merchant_reference} and {status} are expanded
+	SuccessURL string `json:"success_url,omitempty"`
+	// CancelURL is where the customer is sent after cancelling, with the same placeholders as SuccessURL
+	CancelURL string `json:"cancel_url,omitempty"`
+}
 
 // ExternalCreatePaymentRequest represents the request for creating an external payment
 type ExternalCreatePaymentRequest struct {
 	ProductID      string `json:"product_id"`
 	ProductTokenID string `json:"product_token_id"`
 	Count          int    `json:"count"`
+	PaymentOptions
 }
 
 // ExternalCreatePaymentResponse represents the response for creating an external payment
@@ -271,21 +342,30 @@ type ExternalCreatePaymentResponse struct {
 	Message          string             `json:"message"`
 	PaymentID        string             `json:"payment_id"`
 	PayLink          string             `json:"pay_link"`
-	ContractAddress  string             `json:"contract_address"`
+	ContractAddress  Address            `json:"contract_address"`
 	PaymentReceivers []*PaymentReceiver `json:"payment_receivers"`
-	TokenAddress     string             `json:"token_address"`
+	TokenAddress     Address            `json:"token_address"`
 	Decimals         int                `json:"decimals"`
+	ExpiresAt        string             `json:"expires_at,omitempty"`
 }
 
-
 // ExternalCreatePayment creates a new external payment
 func (c *Client) ExternalCreatePayment(req *ExternalCreatePaymentRequest) (*ExternalCreatePaymentResponse, error) {
+	return c.externalCreatePayment(context.Background(), req)
+}
+
+func (c *Client) externalCreatePayment(ctx context.Context, req *ExternalCreatePaymentRequest) (*ExternalCreatePaymentResponse, error) {
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 	url := c.url + "/external/payments"
-	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -312,3 +392,8 @@ func (c *Client) ExternalCreatePayment(req *ExternalCreatePaymentRequest) (*Exte
 	}
 	return &response, nil
 }
+
+// IsTerminal reports whether the payment has reached a final status (paid, closed or expired)
+func (p *Payment) IsTerminal() bool {
+	return IsTerminalPaymentStatus(p.Status)
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultPortfolioConcurrency is the number of chains queried in parallel by GetPortfolio
+const DefaultPortfolioConcurrency = 4
+
+// PortfolioOptions configures GetPortfolio
+type PortfolioOptions struct {
+	// ChainIDs limits the query to these chains; all chains with active tokens are queried if empty
+	ChainIDs []int
+	// TokenSymbol limits the query to one token symbol
+	TokenSymbol string
+	// Concurrency is the maximum number of chains queried at once
+	Concurrency int
+}
+
+// ChainBalances holds the balances of a wallet on one chain
+type ChainBalances struct {
+	ChainID   int
+	ChainName string
+	Balances  []TokenBalance
+	// Totals is the balance per token symbol on this chain
+	Totals map[string]Amount
+}
+
+// Portfolio is the merged view of a wallet's balances across chains
+type Portfolio struct {
+	WalletAddress Address
+	// Chains holds the balances of every chain that was queried successfully, ordered by chain ID
+	Chains []*ChainBalances
+	// Totals is the balance per token symbol summed across chains
+	Totals map[string]Amount
+	// Errors holds the error of every chain that failed, keyed by chain ID
+	Errors map[int]error
+}
+
+// Complete reports whether every chain was queried successfully
+func (p *Portfolio) Complete() bool {
+	return len(p.Errors) == 0
+}
+
+// Amount returns the balance as a lossless amount
+func (b *TokenBalance) Amount() (Amount, error) {
+	return ParseBaseUnits(b.Balance, b.Decimals)
+}
+
+// GetPortfolio queries the wallet's balances on every supported chain concurrently and merges them.
+// Chains that fail are reported in Portfolio.Errors; an error is returned only if no chain succeeded.
+// Cancelling ctx aborts the queries in flight and fails the chains not yet queried.
+func (c *Client) GetPortfolio(ctx context.Context, walletAddress string, opts *PortfolioOptions) (*Portfolio, error) {
+	if !c.skipValidation {
+		var fe fieldErrors
+		fe.address("wallet_address", Address(walletAddress))
+		if err := fe.err("GetPortfolio"); err != nil {
+			return nil, err
+		}
+	}
+	if opts == nil {
+		opts = &PortfolioOptions{}
+	}
+	chainIDs := opts.ChainIDs
+	if len(chainIDs) == 0 {
+		chains, err := c.chainRegistry.ListActive()
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range chains {
+			chainIDs = append(chainIDs, ch.ID)
+		}
+	}
+	if len(chainIDs) == 0 {
+		return nil, errors.New("no supported chains")
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPortfolioConcurrency
+	}
+
+	type result struct {
+		chainID int
+		resp    *BalanceResponse
+		err     error
+	}
+	results := make(chan result, len(chainIDs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, id := range chainIDs {
+		wg.Add(1)
+		go func(chainID int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results <- result{chainID: chainID, err: ctx.Err()}
+				return
+			}
+			resp, err := c.getTokenBalances(ctx, walletAddress, chainID, opts.TokenSymbol)
+			results <- result{chainID: chainID, resp: resp, err: err}
+		}(id)
+	}
+	wg.Wait()
+	close(results)
+
+	portfolio := &Portfolio{
+		WalletAddress: Address(walletAddress).Checksum(),
+		Totals:        make(map[string]Amount),
+		Errors:        make(map[int]error),
+	}
+	for r := range results {
+		if r.err != nil {
+			portfolio.Errors[r.chainID] = r.err
+			continue
+		}
+		cb := &ChainBalances{
+			ChainID:   r.chainID,
+			ChainName: r.resp.ChainName,
+			Balances:  r.resp.Balances,
+			Totals:    make(map[string]Amount),
+		}
+		for i := range r.resp.Balances {
+			b := &r.resp.Balances[i]
+			amount, err := b.Amount()
+			if err != nil {
+				portfolio.Errors[r.chainID] = fmt.Errorf("token %s: %w", b.Symbol, err)
+				continue
+			}
+			symbol := strings.ToUpper(b.Symbol)
+			cb.Totals[symbol] = addAmount(cb.Totals, symbol, amount)
+			portfolio.Totals[symbol] = addAmount(portfolio.Totals, symbol, amount)
+		}
+		portfolio.Chains = append(portfolio.Chains, cb)
+	}
+	sort.Slice(portfolio.Chains, func(i, j int) bool { return portfolio.Chains[i].ChainID < portfolio.Chains[j].ChainID })
+
+	if len(portfolio.Chains) == 0 {
+		errs := make([]error, 0, len(portfolio.Errors))
+		for id, err := range portfolio.Errors {
+			errs = append(errs, fmt.Errorf("chain %d: %w", id, err))
+		}
+		return portfolio, fmt.Errorf("failed to query balances on all chains: %w", errors.Join(errs...))
+	}
+	return portfolio, nil
+}
+
+func addAmount(totals map[string]Amount, key string, amount Amount) Amount {
+	if total, ok := totals[key]; ok {
+		return total.Add(amount)
+	}
+	return amount
+}
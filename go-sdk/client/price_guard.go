@@ -0,0 +1,117 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// PriceBounds is an inclusive price range in human units, e.g. Min "0.01" and Max "10000".
+// An empty bound is not checked.
+type PriceBounds struct {
+	Min string
+	Max string
+}
+
+// PriceGuard rejects product prices that are most likely unit mistakes, such as
+// a price of "1" on an 18-decimal token (1 wei). Prices are converted to human
+// units with the token's decimals from the token registry before being compared.
+type PriceGuard struct {
+	// Default applies to every token without a symbol specific entry
+	Default PriceBounds
+	// BySymbol overrides the bounds for a token symbol, e.g. "ETH"
+	BySymbol map[string]PriceBounds
+}
+
+// WithPriceGuard enables price sanity checks in CreateProduct and AddProductToken
+func WithPriceGuard(guard *PriceGuard) Option {
+	return func(c *Client) {
+		c.priceGuard = guard
+	}
+}
+
+// PriceRangeError is returned when a price falls outside the configured bounds
+type PriceRangeError struct {
+	TokenID  string
+	Symbol   string
+	Decimals int
+	Price    string // base units as sent to the server
+	Amount   Amount // the price interpreted in human units
+	Bound    string // the bound that was violated, in human units
+	TooLow   bool
+}
+
+func (e *PriceRangeError) Error() string {
+	direction, limit := "above the maximum", "at most"
+	if e.TooLow {
+		direction, limit = "below the minimum", "at least"
+	}
+	msg := fmt.Sprintf("price %s for token %s (%d decimals) is %s %s, %s of %s %s; "+
+		"prices are given in the token's smallest unit (1 %s = 10^%d)",
+		e.Price, e.Symbol, e.Decimals, e.Amount.String(), e.Symbol, direction, e.Bound, e.Symbol,
+		e.Symbol, e.Decimals)
+	if bound, err := ParseUnits(e.Bound, e.Decimals); err == nil {
+		msg += fmt.Sprintf(", so the price must be %s %s", limit, bound.BaseUnits())
+	}
+	return msg
+}
+
+func (g *PriceGuard) bounds(symbol string) PriceBounds {
+	if b, ok := g.BySymbol[symbol]; ok {
+		return b
+	}
+	return g.Default
+}
+
+// Check verifies a base unit price against the bounds for the given token
+func (g *PriceGuard) Check(price string, token *Token) error {
+	amount, err := ParseBaseUnits(price, token.Decimals)
+	if err != nil {
+		return err
+	}
+	human := amount.Rat()
+	b := g.bounds(token.Symbol)
+
+	check := func(bound string, tooLow bool) error {
+		if bound == "" {
+			return nil
+		}
+		limit, ok := new(big.Rat).SetString(bound)
+		if !ok {
+			return fmt.Errorf("invalid price bound %q for %s", bound, token.Symbol)
+		}
+		cmp := human.Cmp(limit)
+		if (tooLow && cmp < 0) || (!tooLow && cmp > 0) {
+			return &PriceRangeError{
+				TokenID:  token.TokenID,
+				Symbol:   token.Symbol,
+				Decimals: token.Decimals,
+				Price:    price,
+				Amount:   amount,
+				Bound:    bound,
+				TooLow:   tooLow,
+			}
+		}
+		return nil
+	}
+	if err := check(b.Min, true); err != nil {
+		return err
+	}
+	return check(b.Max, false)
+}
+
+// checkPrice runs the configured price guard against every token the price applies to
+func (c *Client) checkPrice(price string, tokenIDs ...string) error {
+	if c.priceGuard == nil {
+		return nil
+	}
+	for _, id := range tokenIDs {
+		token, err := c.tokenRegistry.ByID(id)
+		if err != nil {
+			return fmt.Errorf("price check: %w", err)
+		}
+		if err := c.priceGuard.Check(price, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,40 +15,40 @@ type CreateProductRequest struct {
 	Content          string   `json:"content"`
 	TokenIDList      []string `json:"token_ids"`
 	Price            string   `json:"price"`
-	RecipientAddress string   `json:"recipient_address"`
+	RecipientAddress Address  `json:"recipient_address"`
 }
 
 // ProductToken represents a product token information
 type ProductToken struct {
-	ProductTokenID       string `json:"product_token_id"`
-	ProductID            string `json:"product_id"`
-	AccountID            string `json:"account_id"`
-	TokenID              string `json:"token_id"`
-	Price                string `json:"price"`
-	RecipientAddress     string `json:"recipient_address"`
-	PaymentRouterAddress string `json:"payment_router_address"`
-	CreatedAt            string `json:"created_at"`
-	ChainID              string `json:"chain_id"`
-	ChainName            string `json:"chain_name"`
+	ProductTokenID       string  `json:"product_token_id"`
+	ProductID            string  `json:"product_id"`
+	AccountID            string  `json:"account_id"`
+	TokenID              string  `json:"token_id"`
+	Price                string  `json:"price"`
+	RecipientAddress     Address `json:"recipient_address"`
+	PaymentRouterAddress Address `json:"payment_router_address"`
+	CreatedAt            string  `json:"created_at"`
+	ChainID              string  `json:"chain_id"`
+	ChainName            string  `json:"chain_name"`
 }
 
 // Product represents a product information
 type Product struct {
-	ProductID       string         `json:"product_id"`
-	AccountID       string         `json:"account_id"`
-	Name            string         `json:"name"`
-	Description     string         `json:"description,omitempty"`
-	Content         string         `json:"content"`
-	Active          bool           `json:"active"`
+	ProductID       string          `json:"product_id"`
+	AccountID       string          `json:"account_id"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description,omitempty"`
+	Content         string          `json:"content"`
+	Active          bool            `json:"active"`
 	ProductTokens   []*ProductToken `json:"product_tokens"`
-	CreatedAt       string         `json:"created_at"`
-	TotalSaleCount  int64          `json:"total_sale_count"`
-	TotalSaleAmount float64        `json:"total_sale_amount"`
+	CreatedAt       string          `json:"created_at"`
+	TotalSaleCount  int64           `json:"total_sale_count"`
+	TotalSaleAmount float64         `json:"total_sale_amount"`
 }
 
 // CreateProductResponse represents the response for creating a product
 type CreateProductResponse struct {
-	Message string  `json:"message"`
+	Message string   `json:"message"`
 	Product *Product `json:"product"`
 }
 
@@ -60,14 +60,14 @@ type ListProductsResponse struct {
 
 // AddProductTokenRequest represents the request for adding a token to a product
 type AddProductTokenRequest struct {
-	TokenID          string `json:"token_id"`
-	Price            string `json:"price"`
-	RecipientAddress string `json:"recipient_address"`
+	TokenID          string  `json:"token_id"`
+	Price            string  `json:"price"`
+	RecipientAddress Address `json:"recipient_address"`
 }
 
 // AddProductTokenResponse represents the response for adding a token to a product
 type AddProductTokenResponse struct {
-	Message      string       `json:"message"`
+	Message      string        `json:"message"`
 	ProductToken *ProductToken `json:"product_token"`
 }
 
@@ -87,14 +87,31 @@ type ProductTokenStatus struct {
 
 // GetProductTokenStatusResponse represents the response for getting product token status
 type GetProductTokenStatusResponse struct {
-	Message string                 `json:"message"`
+	Message string                `json:"message"`
 	Status  []*ProductTokenStatus `json:"status"`
 }
 
 // CreateProduct creates a new product
 func (c *Client) CreateProduct(req *CreateProductRequest) (*CreateProductResponse, error) {
+	// 校验请求
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.checkPrice(req.Price, req.TokenIDList...); err != nil {
+		return nil, err
+	}
+	// 地址校验不受 skipValidation 影响
+	recipient, err := ParseAddress(string(req.RecipientAddress))
+	if err != nil {
+		return nil, fmt.Errorf("recipient_address: %w", err)
+	}
+	normalized := *req
+	normalized.RecipientAddress = recipient
+
 	// 构建请求
-	jsonData, err := json.Marshal(req)
+	jsonData, err := json.Marshal(&normalized)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
@@ -224,8 +241,25 @@ func (c *Client) GetProduct(productID string) (*Product, error) {
 
 // AddProductToken adds a token to a specific product
 func (c *Client) AddProductToken(productID string, req *AddProductTokenRequest) (*AddProductTokenResponse, error) {
+	// 校验请求
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.checkPrice(req.Price, req.TokenID); err != nil {
+		return nil, err
+	}
+	// 地址校验不受 skipValidation 影响
+	recipient, err := ParseAddress(string(req.RecipientAddress))
+	if err != nil {
+		return nil, fmt.Errorf("recipient_address: %w", err)
+	}
+	normalized := *req
+	normalized.RecipientAddress = recipient
+
 	// 构建请求
-	jsonData, err := json.Marshal(req)
+	jsonData, err := json.Marshal(&normalized)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// QuoteOption is one token a customer can pay a product with
+type QuoteOption struct {
+	ProductTokenID string
+	TokenID        string
+	Symbol         string
+	Name           string
+	IconURL        string
+	ChainID        int
+	ChainName      string
+	Decimals       int
+	// UnitPrice is the price of one item
+	UnitPrice Amount
+	// Total is UnitPrice times the quoted count
+	Total Amount
+	// Balance is the wallet's balance of the token, nil if no wallet was given or it is unavailable
+	Balance *Amount
+	// Sufficient is set when Balance covers Total
+	Sufficient bool
+	// Shortfall is how much more the wallet needs, zero if Sufficient or Balance is unknown
+	Shortfall Amount
+}
+
+// Quote lists the ways a customer can pay for a product, best option first
+type Quote struct {
+	ProductID     string
+	ProductName   string
+	Count         int
+	WalletAddress Address
+	Options       []*QuoteOption
+	// Skipped lists the product token IDs left out because their token is inactive or unknown
+	Skipped []string
+	// BalanceErrors holds the balance query error of every chain that failed, keyed by chain ID
+	BalanceErrors map[int]error
+}
+
+// Affordable returns the options the wallet has enough balance for
+func (q *Quote) Affordable() []*QuoteOption {
+	var options []*QuoteOption
+	for _, o := range q.Options {
+		if o.Sufficient {
+			options = append(options, o)
+		}
+	}
+	return options
+}
+
+// QuoteProduct prices count items of a product in every active token it accepts. If walletAddress
+// is not empty, the wallet's balances are queried as well and the options the wallet can afford
+// are ranked first. Options are otherwise ordered by chain ID and token symbol.
+func (c *Client) QuoteProduct(ctx context.Context, productID string, count int, walletAddress string) (*Quote, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("count must be at least 1, got %d", count)
+	}
+	if walletAddress != "" {
+		if err := Address(walletAddress).Validate(); err != nil {
+			return nil, err
+		}
+	}
+	product, err := c.GetProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &Quote{
+		ProductID:     product.ProductID,
+		ProductName:   product.Name,
+		Count:         count,
+		WalletAddress: Address(walletAddress).Checksum(),
+		BalanceErrors: make(map[int]error),
+	}
+	chainSet := make(map[int]bool)
+	for _, pt := range product.ProductTokens {
+		token, err := c.tokenRegistry.ByID(pt.TokenID)
+		if errors.Is(err, ErrTokenNotFound) {
+			quote.Skipped = append(quote.Skipped, pt.ProductTokenID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !token.IsActive {
+			quote.Skipped = append(quote.Skipped, pt.ProductTokenID)
+			continue
+		}
+		price, err := ParseBaseUnits(pt.Price, token.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("product token %s: %w", pt.ProductTokenID, err)
+		}
+		total := NewAmount(new(big.Int).Mul(price.Value, big.NewInt(int64(count))), token.Decimals)
+		quote.Options = append(quote.Options, &QuoteOption{
+			ProductTokenID: pt.ProductTokenID,
+			TokenID:        token.TokenID,
+			Symbol:         token.Symbol,
+			Name:           token.Name,
+			IconURL:        token.IconURL,
+			ChainID:        token.ChainID,
+			ChainName:      token.ChainName,
+			Decimals:       token.Decimals,
+			UnitPrice:      price,
+			Total:          total,
+			Shortfall:      NewAmount(nil, token.Decimals),
+		})
+		chainSet[token.ChainID] = true
+	}
+
+	if walletAddress != "" && len(chainSet) > 0 {
+		chainIDs := make([]int, 0, len(chainSet))
+		for id := range chainSet {
+			chainIDs = append(chainIDs, id)
+		}
+		sort.Ints(chainIDs)
+		// 全部链失败时仍返回报价，只是没有余额信息
+		portfolio, _ := c.GetPortfolio(ctx, walletAddress, &PortfolioOptions{ChainIDs: chainIDs})
+		if portfolio != nil {
+			for id, err := range portfolio.Errors {
+				quote.BalanceErrors[id] = err
+			}
+			quote.applyBalances(portfolio)
+		}
+	}
+
+	sort.SliceStable(quote.Options, func(i, j int) bool {
+		a, b := quote.Options[i], quote.Options[j]
+		if a.Sufficient != b.Sufficient {
+			return a.Sufficient
+		}
+		if a.ChainID != b.ChainID {
+			return a.ChainID < b.ChainID
+		}
+		return a.Symbol < b.Symbol
+	})
+	return quote, nil
+}
+
+func (q *Quote) applyBalances(portfolio *Portfolio) {
+	byToken := make(map[string]*TokenBalance)
+	for _, cb := range portfolio.Chains {
+		for i := range cb.Balances {
+			b := &cb.Balances[i]
+			byToken[b.TokenID] = b
+			byToken[fmt.Sprintf("%d:%s", cb.ChainID, strings.ToLower(b.Symbol))] = b
+		}
+	}
+	for _, o := range q.Options {
+		b, ok := byToken[o.TokenID]
+		if !ok {
+			b, ok = byToken[fmt.Sprintf("%d:%s", o.ChainID, strings.ToLower(o.Symbol))]
+		}
+		if !ok {
+			continue
+		}
+		balance, err := ParseBaseUnits(b.Balance, o.Decimals)
+		if err != nil {
+			q.BalanceErrors[o.ChainID] = fmt.Errorf("token %s: %w", o.Symbol, err)
+			continue
+		}
+		o.Balance = &balance
+		o.Sufficient = balance.Cmp(o.Total) >= 0
+		if !o.Sufficient {
+			o.Shortfall = o.Total.Sub(balance)
+		}
+	}
+}
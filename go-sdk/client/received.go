@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// How the amount a payment received compares with its TotalAmount
+const (
+	PaymentAmountExact     = "exact"
+	PaymentAmountUnderpaid = "underpaid"
+	PaymentAmountOverpaid  = "overpaid"
+	// PaymentAmountUnpaid means the payment is not paid and the server reports no received amount
+	PaymentAmountUnpaid = "unpaid"
+)
+
+var (
+	// ErrReceivedAmountUnknown is returned when the received amount of a payment cannot be determined
+	ErrReceivedAmountUnknown = errors.New("received amount unknown")
+	// ErrAmountFlagConflict is returned when the PartiallyPaid or Overpaid flag contradicts the amounts
+	ErrAmountFlagConflict = errors.New("payment amount flags contradict the received amount")
+)
+
+// AmountCheck compares the amount a payment received with the amount it asked for
+type AmountCheck struct {
+	// Result is PaymentAmountExact, PaymentAmountUnderpaid, PaymentAmountOverpaid or PaymentAmountUnpaid
+	Result   string
+	Expected Amount
+	Received Amount
+	// Shortfall is how much is missing when underpaid or unpaid, zero otherwise
+	Shortfall Amount
+	// Excess is how much too much was sent when overpaid, zero otherwise
+	Excess Amount
+}
+
+// ReceivedValue returns the amount the payment actually received. If the server does not
+// report it, a paid payment without the PartiallyPaid or Overpaid flag is assumed to have
+// received TotalAmount; any other payment returns ErrReceivedAmountUnknown.
+func (p *Payment) ReceivedValue(decimals int) (Amount, error) {
+	if p.ReceivedAmount != "" {
+		return ParseBaseUnits(p.ReceivedAmount, decimals)
+	}
+	if p.Status == PaymentStatusPaid && !p.PartiallyPaid && !p.Overpaid {
+		return ParseBaseUnits(p.TotalAmount, decimals)
+	}
+	return Amount{}, ErrReceivedAmountUnknown
+}
+
+// CheckAmount classifies the payment as exact, underpaid, overpaid or unpaid. decimals are the
+// decimals of the payment's token, see TokenRegistry.ByID. If the server's PartiallyPaid or
+// Overpaid flag contradicts the amounts, ErrAmountFlagConflict is returned.
+func (p *Payment) CheckAmount(decimals int) (*AmountCheck, error) {
+	expected, err := ParseBaseUnits(p.TotalAmount, decimals)
+	if err != nil {
+		return nil, err
+	}
+	check := &AmountCheck{
+		Result:    PaymentAmountExact,
+		Expected:  expected,
+		Received:  NewAmount(nil, decimals),
+		Shortfall: NewAmount(nil, decimals),
+		Excess:    NewAmount(nil, decimals),
+	}
+	if p.ReceivedAmount == "" && p.Status != PaymentStatusPaid && !p.PartiallyPaid && !p.Overpaid {
+		check.Result = PaymentAmountUnpaid
+		check.Shortfall = expected
+		return check, nil
+	}
+	received, err := p.ReceivedValue(decimals)
+	if err != nil {
+		return nil, err
+	}
+	check.Received = received
+	switch received.Cmp(expected) {
+	case -1:
+		check.Result = PaymentAmountUnderpaid
+		check.Shortfall = expected.Sub(received)
+	case 1:
+		check.Result = PaymentAmountOverpaid
+		check.Excess = received.Sub(expected)
+	}
+	// 服务端标记与金额不一致时报错，不自行取舍
+	switch {
+	case p.PartiallyPaid && p.Overpaid:
+		return nil, fmt.Errorf("%w: payment %s is flagged both partially paid and overpaid", ErrAmountFlagConflict, p.PaymentID)
+	case p.PartiallyPaid && check.Result != PaymentAmountUnderpaid:
+		return nil, fmt.Errorf("%w: payment %s is flagged partially paid, but received %s of %s", ErrAmountFlagConflict, p.PaymentID, received, expected)
+	case p.Overpaid && check.Result != PaymentAmountOverpaid:
+		return nil, fmt.Errorf("%w: payment %s is flagged overpaid, but received %s of %s", ErrAmountFlagConflict, p.PaymentID, received, expected)
+	}
+	return check, nil
+}
+
+// CheckAmountOn classifies the payment like CheckAmount, looking up the token's decimals in the registry
+func (p *Payment) CheckAmountOn(registry *TokenRegistry) (*AmountCheck, error) {
+	token, err := registry.ByID(p.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	return p.CheckAmount(token.Decimals)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Placeholders that the server expands in SuccessURL and CancelURL
+const (
+	RedirectPlaceholderPaymentID         = "{payment_id}"
+	RedirectPlaceholderMerchantReference = "{merchant_reference}"
+	RedirectPlaceholderStatus            = "{status}"
+)
+
+// ErrRedirectNotPaid is returned by ConfirmRedirect when the payment behind a success redirect is not paid
+var ErrRedirectNotPaid = errors.New("payment of redirect is not paid")
+
+var redirectPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+var knownRedirectPlaceholders = map[string]bool{
+	RedirectPlaceholderPaymentID:         true,
+	RedirectPlaceholderMerchantReference: true,
+	RedirectPlaceholderStatus:            true,
+}
+
+func validateRedirectURL(fe *fieldErrors, field, raw string) {
+	if raw == "" {
+		return
+	}
+	for _, p := range redirectPlaceholder.FindAllString(raw, -1) {
+		if !knownRedirectPlaceholders[p] {
+			fe.add(field, "unknown placeholder %s", p)
+		}
+	}
+	// 占位符替换后再解析，避免花括号影响 URL 校验
+	u, err := url.Parse(redirectPlaceholder.ReplaceAllString(raw, "x"))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		fe.add(field, "must be an absolute http(s) URL, got %q", raw)
+	}
+}
+
+// ConfirmRedirect checks a success redirect against the API, so that a customer cannot fake
+// one by opening the SuccessURL directly. Put {payment_id} in SuccessURL and pass the value
+// it expands to. If merchantReference is not empty it must match the payment as well.
+// The payment is returned together with ErrRedirectNotPaid if it is not paid.
+func (c *Client) ConfirmRedirect(paymentID, merchantReference string) (*Payment, error) {
+	if strings.TrimSpace(paymentID) == "" {
+		return nil, errors.New("redirect has no payment ID")
+	}
+	payment, err := c.GetPaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if merchantReference != "" && payment.MerchantReference != merchantReference {
+		return nil, fmt.Errorf("payment %s belongs to merchant reference %q, not %q", paymentID, payment.MerchantReference, merchantReference)
+	}
+	if payment.Status != PaymentStatusPaid {
+		return payment, fmt.Errorf("%w: payment %s is %s", ErrRedirectNotPaid, paymentID, payment.Status)
+	}
+	return payment, nil
+}
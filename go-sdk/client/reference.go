@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrPaymentNotFound is returned when no payment matches a lookup
+var ErrPaymentNotFound = errors.New("payment not found")
+
+// GetPaymentByMerchantReference retrieves the payment created with the given merchant reference
+func (c *Client) GetPaymentByMerchantReference(ctx context.Context, reference string) (*Payment, error) {
+	reqURL := c.url + "/payments/reference/" + url.PathEscape(reference)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.tokenHolder != nil {
+		req.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: merchant reference %q", ErrPaymentNotFound, reference)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var payment Payment
+	if err := json.Unmarshal(body, &payment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &payment, nil
+}
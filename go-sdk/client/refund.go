@@ -0,0 +1,275 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrRefundNotFound is returned when a refund does not exist
+var ErrRefundNotFound = errors.New("refund not found")
+
+// Refund statuses. A refund starts as pending, moves to processing once the
+// transfer is submitted on-chain and ends as succeeded or failed.
+const (
+	RefundStatusPending    = "pending"
+	RefundStatusProcessing = "processing"
+	RefundStatusSucceeded  = "succeeded"
+	RefundStatusFailed     = "failed"
+)
+
+// Refund represents a full or partial refund of a paid payment
+type Refund struct {
+	RefundID        string  `json:"refund_id"`
+	PaymentID       string  `json:"payment_id"`
+	AccountID       string  `json:"account_id"`
+	TokenID         string  `json:"token_id"`
+	Amount          string  `json:"amount"` // wei format
+	Decimals        int     `json:"decimals"`
+	Reason          string  `json:"reason,omitempty"`
+	Status          string  `json:"status"`
+	ToAddress       Address `json:"to_address,omitempty"`
+	TransactionHash string  `json:"transaction_hash,omitempty"`
+	FailureReason   string  `json:"failure_reason,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+	CompletedAt     string  `json:"completed_at,omitempty"`
+}
+
+// IsTerminal reports whether the refund has succeeded or failed
+func (r *Refund) IsTerminal() bool {
+	return r.Status == RefundStatusSucceeded || r.Status == RefundStatusFailed
+}
+
+// AmountValue returns the refund amount as a lossless amount
+func (r *Refund) AmountValue() (Amount, error) {
+	return ParseBaseUnits(r.Amount, r.Decimals)
+}
+
+// CreateRefundRequest represents the request for refunding a payment
+type CreateRefundRequest struct {
+	PaymentID string `json:"payment_id"`
+	// Amount in the token's smallest unit; the full refundable amount is refunded if empty
+	Amount string `json:"amount,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// ToAddress overrides the refund destination, which defaults to the payer's address
+	ToAddress Address `json:"to_address,omitempty"`
+
+	decimals *int // set by SetAmount, checked against the payment's token
+}
+
+// SetAmount sets the refund amount from a human or base unit Amount.
+// CreateRefund then checks that the Amount's decimals match the payment's token.
+func (r *CreateRefundRequest) SetAmount(a Amount) {
+	r.Amount = a.BaseUnits()
+	decimals := a.Decimals
+	r.decimals = &decimals
+}
+
+// Validate checks the request before it is sent to the server
+func (r *CreateRefundRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("payment_id", r.PaymentID)
+	if r.Amount != "" {
+		fe.baseUnits("amount", r.Amount)
+	}
+	if r.ToAddress != "" {
+		fe.address("to_address", r.ToAddress)
+	}
+	return fe.err("CreateRefundRequest")
+}
+
+// CreateRefundResponse represents the response for creating a refund
+type CreateRefundResponse struct {
+	Message string   `json:"message"`
+	Status  string   `json:"status,omitempty"` // current payment status when the refund is rejected
+	Refund  *Refund  `json:"refund"`
+	Payment *Payment `json:"payment,omitempty"`
+}
+
+// ListRefundsResponse represents the response for listing the refunds of a payment
+type ListRefundsResponse struct {
+	Message string    `json:"message"`
+	Refunds []*Refund `json:"refunds"`
+}
+
+// RefundedValue returns the total amount refunded so far
+func (p *Payment) RefundedValue(decimals int) (Amount, error) {
+	if p.RefundedAmount == "" {
+		return NewAmount(nil, decimals), nil
+	}
+	return ParseBaseUnits(p.RefundedAmount, decimals)
+}
+
+// RefundableValue returns the part of TotalAmount that has not been refunded yet
+func (p *Payment) RefundableValue(decimals int) (Amount, error) {
+	total, err := ParseBaseUnits(p.TotalAmount, decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	refunded, err := p.RefundedValue(decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	return total.Sub(refunded), nil
+}
+
+// CreateRefund refunds a paid payment fully or partly. Refunding a payment that is not paid
+// fails with ErrPaymentNotPaid. If the amount was set with SetAmount, the payment is fetched
+// first and an Amount whose decimals differ from the payment's token is rejected.
+func (c *Client) CreateRefund(ctx context.Context, req *CreateRefundRequest) (*Refund, error) {
+	if !c.skipValidation {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if req.decimals != nil {
+		if err := c.checkRefundDecimals(ctx, req.PaymentID, *req.decimals); err != nil {
+			return nil, err
+		}
+	}
+	normalized := *req
+	if req.ToAddress != "" {
+		normalized.ToAddress = req.ToAddress.Checksum()
+	}
+	reqBody, err := json.Marshal(&normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	reqURL := c.url + "/payments/" + url.PathEscape(req.PaymentID) + "/refunds"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.tokenHolder != nil {
+		httpReq.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var response CreateRefundResponse
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentNotFound, req.PaymentID)
+	}
+	if resp.StatusCode == http.StatusConflict {
+		// 支付状态不允许退款
+		if err := json.Unmarshal(body, &response); err == nil {
+			status := response.Status
+			if status == "" && response.Payment != nil {
+				status = response.Payment.Status
+			}
+			if status != "" {
+				return nil, &PaymentStateError{PaymentID: req.PaymentID, Status: status, Message: response.Message}
+			}
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Refund == nil {
+		return nil, fmt.Errorf("API response missing refund")
+	}
+	return response.Refund, nil
+}
+
+// checkRefundDecimals fetches the payment and compares decimals with its token's decimals
+func (c *Client) checkRefundDecimals(ctx context.Context, paymentID string, decimals int) error {
+	payment, err := c.getPaymentByID(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+	if payment.Status != PaymentStatusPaid {
+		return &PaymentStateError{PaymentID: paymentID, Status: payment.Status, Message: "only paid payments can be refunded"}
+	}
+	token, err := c.tokenRegistry.ByID(payment.TokenID)
+	if err != nil {
+		return err
+	}
+	if token.Decimals != decimals {
+		return fmt.Errorf("refund amount has %d decimals, but token %s of payment %s has %d", decimals, token.TokenID, paymentID, token.Decimals)
+	}
+	return nil
+}
+
+// GetRefund retrieves a specific refund of a payment
+func (c *Client) GetRefund(ctx context.Context, paymentID, refundID string) (*Refund, error) {
+	reqURL := c.url + "/payments/" + url.PathEscape(paymentID) + "/refunds/" + url.PathEscape(refundID)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.tokenHolder != nil {
+		req.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRefundNotFound, refundID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var refund Refund
+	if err := json.Unmarshal(body, &refund); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &refund, nil
+}
+
+// ListRefunds retrieves all refunds of a payment
+func (c *Client) ListRefunds(ctx context.Context, paymentID string) (*ListRefundsResponse, error) {
+	reqURL := c.url + "/payments/" + url.PathEscape(paymentID) + "/refunds"
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.tokenHolder != nil {
+		req.Header.Set("Authorization", "Bearer "+c.tokenHolder.getToken())
+	}
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentNotFound, paymentID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var response ListRefundsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
@@ -9,20 +9,20 @@ import (
 
 // Token represents a single token information
 type Token struct {
-	TokenID         string `json:"token_id"`
-	Name            string `json:"name"`
-	Symbol          string `json:"symbol"`
-	ContractAddress string `json:"contract_address"`
-	Decimals        int    `json:"decimals"`
-	ChainID         int    `json:"chain_id"`
-	ChainName       string `json:"chain_name"`
-	ChainSymbol     string `json:"chain_symbol"`
-	ExplorerURL     string `json:"explorer_url"`
-	IconURL         string `json:"icon_url"`
-	TokenType       string `json:"token_type"`
-	IsActive        bool   `json:"is_active"`
-	CurrencyType    string `json:"currency_type"`
-	CreatedAt       string `json:"created_at"`
+	TokenID         string  `json:"token_id"`
+	Name            string  `json:"name"`
+	Symbol          string  `json:"symbol"`
+	ContractAddress Address `json:"contract_address"`
+	Decimals        int     `json:"decimals"`
+	ChainID         int     `json:"chain_id"`
+	ChainName       string  `json:"chain_name"`
+	ChainSymbol     string  `json:"chain_symbol"`
+	ExplorerURL     string  `json:"explorer_url"`
+	IconURL         string  `json:"icon_url"`
+	TokenType       string  `json:"token_type"`
+	IsActive        bool    `json:"is_active"`
+	CurrencyType    string  `json:"currency_type"`
+	CreatedAt       string  `json:"created_at"`
 }
 
 // ListTokensResponse represents the response for listing tokens
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTokenCacheTTL is how long the token registry serves cached tokens before reloading them
+const DefaultTokenCacheTTL = 5 * time.Minute
+
+// ErrTokenNotFound is returned by registry lookups that match no token
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenRegistry caches the ListTokens response and provides lookups over it.
+// The cache is loaded on first use and then refreshed in the background every TTL.
+// It is safe for concurrent use.
+type TokenRegistry struct {
+	ctx  context.Context
+	load func() (*ListTokensResponse, error)
+	ttl  time.Duration
+
+	mutex    sync.RWMutex
+	tokens   []*Token
+	byID     map[string]*Token
+	loadedAt time.Time
+
+	loadMutex sync.Mutex
+	startOnce sync.Once
+}
+
+// NewTokenRegistry creates a registry backed by load. Background refresh stops when ctx is done.
+func NewTokenRegistry(ctx context.Context, load func() (*ListTokensResponse, error), ttl time.Duration) *TokenRegistry {
+	if ttl <= 0 {
+		ttl = DefaultTokenCacheTTL
+	}
+	return &TokenRegistry{
+		ctx:  ctx,
+		load: load,
+		ttl:  ttl,
+	}
+}
+
+// WithTokenCacheTTL sets how long the client's token registry caches ListTokens
+func WithTokenCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.tokenCacheTTL = ttl
+	}
+}
+
+// Tokens returns the client's cached token registry
+func (c *Client) Tokens() *TokenRegistry {
+	return c.tokenRegistry
+}
+
+// Refresh reloads the tokens from the server
+func (r *TokenRegistry) Refresh() error {
+	r.loadMutex.Lock()
+	defer r.loadMutex.Unlock()
+	return r.refreshLocked()
+}
+
+func (r *TokenRegistry) refreshLocked() error {
+	resp, err := r.load()
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*Token, len(resp.Tokens))
+	for _, t := range resp.Tokens {
+		byID[t.TokenID] = t
+	}
+
+	r.mutex.Lock()
+	r.tokens = resp.Tokens
+	r.byID = byID
+	r.loadedAt = time.Now()
+	r.mutex.Unlock()
+	return nil
+}
+
+// snapshot returns the cached tokens, loading them if the cache is empty or expired.
+// A stale cache is still served if reloading fails.
+func (r *TokenRegistry) snapshot() ([]*Token, map[string]*Token, error) {
+	r.mutex.RLock()
+	tokens, byID, loadedAt := r.tokens, r.byID, r.loadedAt
+	r.mutex.RUnlock()
+	if byID != nil && time.Since(loadedAt) < r.ttl {
+		return tokens, byID, nil
+	}
+
+	r.loadMutex.Lock()
+	// 其他协程可能已经完成加载
+	r.mutex.RLock()
+	fresh := r.byID != nil && time.Since(r.loadedAt) < r.ttl
+	r.mutex.RUnlock()
+	var err error
+	if !fresh {
+		err = r.refreshLocked()
+	}
+	r.loadMutex.Unlock()
+
+	r.mutex.RLock()
+	tokens, byID = r.tokens, r.byID
+	r.mutex.RUnlock()
+	if byID == nil {
+		return nil, nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+	if err != nil {
+		logrus.Warnf("failed to refresh tokens, serving cached list: %v", err)
+	}
+	r.startOnce.Do(func() { go r.refreshLoop() })
+	return tokens, byID, nil
+}
+
+func (r *TokenRegistry) refreshLoop() {
+	ticker := time.NewTicker(r.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Refresh(); err != nil {
+				logrus.Errorf("failed to refresh tokens: %v", err)
+			}
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// All returns every known token
+func (r *TokenRegistry) All() ([]*Token, error) {
+	tokens, _, err := r.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return append([]*Token(nil), tokens...), nil
+}
+
+// ActiveOnly returns the tokens that are currently active
+func (r *TokenRegistry) ActiveOnly() ([]*Token, error) {
+	tokens, _, err := r.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	var active []*Token
+	for _, t := range tokens {
+		if t.IsActive {
+			active = append(active, t)
+		}
+	}
+	return active, nil
+}
+
+// ByID returns the token with the given token ID
+func (r *TokenRegistry) ByID(tokenID string) (*Token, error) {
+	_, byID, err := r.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := byID[tokenID]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %s", ErrTokenNotFound, tokenID)
+	}
+	return t, nil
+}
+
+// BySymbol returns the token with the given symbol on a chain. Symbols are matched case-insensitively.
+func (r *TokenRegistry) BySymbol(chainID int, symbol string) (*Token, error) {
+	tokens, _, err := r.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.ChainID == chainID && strings.EqualFold(t.Symbol, symbol) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s on chain %d", ErrTokenNotFound, symbol, chainID)
+}
+
+// ByContract returns the token deployed at the given contract address on a chain
+func (r *TokenRegistry) ByContract(chainID int, addr Address) (*Token, error) {
+	tokens, _, err := r.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.ChainID == chainID && t.ContractAddress.Equal(addr) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: contract %s on chain %d", ErrTokenNotFound, addr, chainID)
+}
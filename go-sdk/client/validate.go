@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FieldError describes a single invalid field of a request
+type FieldError struct {
+	Field   string
+	Message string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when a request fails client-side validation.
+// It lists every invalid field so that callers can report them all at once.
+type ValidationError struct {
+	Request string
+	Fields  []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Request, strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes the field errors to errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// fieldErrors accumulates field errors while validating a request
+type fieldErrors []*FieldError
+
+func (fe *fieldErrors) add(field, format string, args ...interface{}) {
+	*fe = append(*fe, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (fe *fieldErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		fe.add(field, "is required")
+	}
+}
+
+func (fe *fieldErrors) address(field string, addr Address) {
+	if strings.TrimSpace(string(addr)) == "" {
+		fe.add(field, "is required")
+		return
+	}
+	if err := addr.Validate(); err != nil {
+		*fe = append(*fe, &FieldError{Field: field, Message: err.Error(), Err: err})
+	}
+}
+
+// baseUnits checks that value is a positive integer amount in the token's smallest unit
+func (fe *fieldErrors) baseUnits(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		fe.add(field, "is required")
+		return
+	}
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		fe.add(field, "must be an integer amount in the token's smallest unit, got %q", value)
+		return
+	}
+	if n.Sign() <= 0 {
+		fe.add(field, "must be greater than zero, got %s", value)
+	}
+}
+
+func (fe fieldErrors) err(request string) error {
+	if len(fe) == 0 {
+		return nil
+	}
+	return &ValidationError{Request: request, Fields: fe}
+}
+
+// Validate checks the request before it is sent to the server
+func (r *CreateProductRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("name", r.Name)
+	if len(r.TokenIDList) == 0 {
+		fe.add("token_ids", "at least one token is required")
+	}
+	seen := make(map[string]bool, len(r.TokenIDList))
+	for i, id := range r.TokenIDList {
+		switch {
+		case strings.TrimSpace(id) == "":
+			fe.add(fmt.Sprintf("token_ids[%d]", i), "is empty")
+		case seen[id]:
+			fe.add(fmt.Sprintf("token_ids[%d]", i), "duplicate token %q", id)
+		}
+		seen[id] = true
+	}
+	fe.baseUnits("price", r.Price)
+	fe.address("recipient_address", r.RecipientAddress)
+	return fe.err("CreateProductRequest")
+}
+
+// Validate checks the request before it is sent to the server
+func (r *AddProductTokenRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("token_id", r.TokenID)
+	fe.baseUnits("price", r.Price)
+	fe.address("recipient_address", r.RecipientAddress)
+	return fe.err("AddProductTokenRequest")
+}
+
+// Validate checks the request before it is sent to the server
+func (r *ExternalCreatePaymentRequest) Validate() error {
+	var fe fieldErrors
+	fe.required("product_id", r.ProductID)
+	fe.required("product_token_id", r.ProductTokenID)
+	if r.Count <= 0 {
+		fe.add("count", "must be greater than zero, got %d", r.Count)
+	}
+	r.PaymentOptions.validate(&fe)
+	return fe.err("ExternalCreatePaymentRequest")
+}
+
+// validate checks the shared payment options
+func (o *PaymentOptions) validate(fe *fieldErrors) {
+	validateExpiry(fe, o.ExpiresIn, o.ExpiresAt)
+	validateRedirectURL(fe, "success_url", o.SuccessURL)
+	validateRedirectURL(fe, "cancel_url", o.CancelURL)
+}
+
+// Validate checks the request before it is sent to the server
+func (r *BalanceRequest) Validate() error {
+	var fe fieldErrors
+	fe.address("wallet_address", r.WalletAddress)
+	if r.ChainID <= 0 {
+		fe.add("chain_id", "must be greater than zero, got %d", r.ChainID)
+	}
+	return fe.err("BalanceRequest")
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WaitOptions configures WaitForPayment. The zero value polls every 2s, backing off up to 30s.
+type WaitOptions struct {
+	// InitialInterval is the delay before the second poll
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every poll that sees no status change
+	Multiplier float64
+	// MaxConsecutiveErrors stops waiting after this many failed polls in a row; 0 retries until ctx ends
+	MaxConsecutiveErrors int
+	// OnStatusChange is called with the payment every time its status changes, including the first poll
+	OnStatusChange func(p *Payment)
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	opts := WaitOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.InitialInterval <= 0 {
+		opts.InitialInterval = 2 * time.Second
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.InitialInterval {
+		opts.MaxInterval = opts.InitialInterval
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 1.5
+	}
+	return opts
+}
+
+// WaitForPayment polls GetPaymentByID until the payment reaches a terminal status
+// (paid, closed or expired) or ctx ends. The delay between polls grows with backoff
+// while the status is unchanged and is reset when it changes.
+// If ctx ends first, the last payment seen is returned together with the context error.
+func (c *Client) WaitForPayment(ctx context.Context, paymentID string, opts *WaitOptions) (*Payment, error) {
+	o := opts.withDefaults()
+	interval := o.InitialInterval
+	var (
+		last     *Payment
+		lastErr  error
+		failures int
+	)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, fmt.Errorf("waiting for payment %s: %w (last error: %v)", paymentID, ctx.Err(), lastErr)
+			}
+			return last, fmt.Errorf("waiting for payment %s: %w", paymentID, ctx.Err())
+		case <-timer.C:
+		}
+
+		payment, err := c.getPaymentByID(ctx, paymentID)
+		if err != nil {
+			lastErr = err
+			failures++
+			if o.MaxConsecutiveErrors > 0 && failures >= o.MaxConsecutiveErrors {
+				return last, fmt.Errorf("waiting for payment %s: %w", paymentID, err)
+			}
+			logrus.Warnf("failed to poll payment %s: %v", paymentID, err)
+		} else {
+			lastErr, failures = nil, 0
+			if last == nil || last.Status != payment.Status {
+				interval = o.InitialInterval
+				if o.OnStatusChange != nil {
+					o.OnStatusChange(payment)
+				}
+			}
+			last = payment
+			if payment.IsTerminal() {
+				return payment, nil
+			}
+		}
+
+		timer.Reset(interval)
+		interval = min(time.Duration(float64(interval)*o.Multiplier), o.MaxInterval)
+	}
+}
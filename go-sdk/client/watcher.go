@@ -0,0 +1,349 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PaymentEventType is the kind of change a PaymentWatcher observed
+type PaymentEventType string
+
+// Payment event types
+const (
+	PaymentEventCreated PaymentEventType = "created"
+	PaymentEventPaid    PaymentEventType = "paid"
+	PaymentEventClosed  PaymentEventType = "closed"
+	PaymentEventExpired PaymentEventType = "expired"
+	PaymentEventUpdated PaymentEventType = "updated"
+)
+
+// PaymentEvent is emitted by a PaymentWatcher for every new or changed payment
+type PaymentEvent struct {
+	Type           PaymentEventType
+	Payment        *Payment
+	PreviousStatus string // empty for created events
+}
+
+// PaymentMark is the last state of a payment seen by a watcher
+type PaymentMark struct {
+	UpdatedAt string `json:"updated_at"`
+	Status    string `json:"status"`
+}
+
+// WatcherCursor is the persistable state of a PaymentWatcher. It records the
+// UpdatedAt high-water mark of every payment so a restart neither replays nor misses events.
+type WatcherCursor struct {
+	Payments map[string]PaymentMark `json:"payments"`
+	// Initialized is set once the first scan has completed
+	Initialized bool `json:"initialized"`
+	// PrunedBefore is the newest UpdatedAt of the terminal payments removed from Payments.
+	// Unknown payments updated at or before it were already reported and are skipped.
+	PrunedBefore string `json:"pruned_before,omitempty"`
+}
+
+// CursorStore persists a watcher cursor between runs
+type CursorStore interface {
+	Load() (*WatcherCursor, error)
+	Save(cursor *WatcherCursor) error
+}
+
+// FileCursorStore stores the cursor as JSON in a file
+type FileCursorStore struct {
+	Path string
+}
+
+// Load reads the cursor from the file. A missing file yields an empty cursor.
+func (s *FileCursorStore) Load() (*WatcherCursor, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &WatcherCursor{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cursor: %w", err)
+	}
+	var cursor WatcherCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// Save writes the cursor atomically by writing a temporary file and renaming it
+func (s *FileCursorStore) Save(cursor *WatcherCursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write cursor: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cursor: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cursor: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// PaymentWatcherOptions configures a PaymentWatcher
+type PaymentWatcherOptions struct {
+	// Interval between scans, default 15s
+	Interval time.Duration
+	// PageSize is the limit passed to ListPaymentsByAccount, default 100.
+	// Every scan reads pages until the last one.
+	PageSize int
+	// Retention is how long terminal payments are kept in the cursor after their last
+	// update before they are pruned, default 24h
+	Retention time.Duration
+	// Store persists the cursor after every scan; the cursor is kept in memory only if nil
+	Store CursorStore
+	// SkipExisting records the payments found by the very first scan without emitting events
+	SkipExisting bool
+	// OnEvent is called for every event; if nil, events are delivered on Events()
+	OnEvent func(PaymentEvent)
+	// Buffer is the capacity of the events channel, default 100
+	Buffer int
+}
+
+// PaymentWatcher detects payment changes by periodically scanning ListPaymentsByAccount,
+// for deployments that cannot receive webhooks
+type PaymentWatcher struct {
+	client *Client
+	opts   PaymentWatcherOptions
+	events chan PaymentEvent
+
+	mutex  sync.Mutex
+	cursor *WatcherCursor
+	// dirty is set when the cursor changed since it was last saved
+	dirty bool
+}
+
+// NewPaymentWatcher creates a watcher. The cursor is loaded from opts.Store when Run starts.
+func (c *Client) NewPaymentWatcher(opts *PaymentWatcherOptions) *PaymentWatcher {
+	o := PaymentWatcherOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 15 * time.Second
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = 100
+	}
+	if o.Retention <= 0 {
+		o.Retention = 24 * time.Hour
+	}
+	if o.Buffer <= 0 {
+		o.Buffer = 100
+	}
+	return &PaymentWatcher{
+		client: c,
+		opts:   o,
+		events: make(chan PaymentEvent, o.Buffer),
+		cursor: &WatcherCursor{Payments: make(map[string]PaymentMark)},
+	}
+}
+
+// Events returns the channel events are delivered on when no OnEvent callback is set.
+// It is closed when Run returns.
+func (w *PaymentWatcher) Events() <-chan PaymentEvent {
+	return w.events
+}
+
+// Cursor returns a copy of the watcher's current cursor
+func (w *PaymentWatcher) Cursor() *WatcherCursor {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	cp := &WatcherCursor{
+		Payments:     make(map[string]PaymentMark, len(w.cursor.Payments)),
+		Initialized:  w.cursor.Initialized,
+		PrunedBefore: w.cursor.PrunedBefore,
+	}
+	for id, m := range w.cursor.Payments {
+		cp.Payments[id] = m
+	}
+	return cp
+}
+
+// Run scans for changes every Interval until ctx ends
+func (w *PaymentWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+	if w.opts.Store != nil {
+		cursor, err := w.opts.Store.Load()
+		if err != nil {
+			return err
+		}
+		if cursor.Payments == nil {
+			cursor.Payments = make(map[string]PaymentMark)
+		}
+		w.mutex.Lock()
+		w.cursor = cursor
+		w.mutex.Unlock()
+	}
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Poll(ctx)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			logrus.Errorf("failed to scan payments: %v", err)
+		}
+		for _, ev := range events {
+			if w.opts.OnEvent != nil {
+				w.opts.OnEvent(ev)
+				continue
+			}
+			select {
+			case w.events <- ev:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err == nil && w.opts.Store != nil && w.takeDirty() {
+			if err := w.opts.Store.Save(w.Cursor()); err != nil {
+				w.mutex.Lock()
+				w.dirty = true
+				w.mutex.Unlock()
+				logrus.Errorf("failed to save payment watcher cursor: %v", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *PaymentWatcher) takeDirty() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	dirty := w.dirty
+	w.dirty = false
+	return dirty
+}
+
+// Poll performs a single scan of every page and returns the events found, advancing the cursor.
+// It is used by Run but can also be called directly to drive the watcher manually.
+// Cancelling ctx aborts the scan without advancing the cursor.
+func (w *PaymentWatcher) Poll(ctx context.Context) ([]PaymentEvent, error) {
+	var payments []*Payment
+	for page := 0; ; page++ {
+		resp, err := w.client.listPaymentsByAccount(ctx, w.opts.PageSize, page*w.opts.PageSize)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, resp.Payments...)
+		if len(resp.Payments) < w.opts.PageSize || (resp.TotalPages > 0 && page+1 >= resp.TotalPages) ||
+			(resp.TotalCount > 0 && (page+1)*w.opts.PageSize >= resp.TotalCount) {
+			break
+		}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	silent := w.opts.SkipExisting && !w.cursor.Initialized
+	var events []PaymentEvent
+	for _, p := range payments {
+		prev, seen := w.cursor.Payments[p.PaymentID]
+		if seen && !isNewer(p.UpdatedAt, prev.UpdatedAt) {
+			continue
+		}
+		pruned := !seen && w.cursor.PrunedBefore != ""
+		if pruned && !isNewer(p.UpdatedAt, w.cursor.PrunedBefore) {
+			continue // 已上报并清理过的终态支付
+		}
+		w.cursor.Payments[p.PaymentID] = PaymentMark{UpdatedAt: p.UpdatedAt, Status: p.Status}
+		w.dirty = true
+		if silent {
+			continue
+		}
+		if pruned && p.CreatedAt != "" && !isNewer(p.CreatedAt, w.cursor.PrunedBefore) {
+			// 已清理的支付再次更新，之前的状态已不可知
+			events = append(events, PaymentEvent{Type: PaymentEventUpdated, Payment: p})
+			continue
+		}
+		if !seen {
+			events = append(events, PaymentEvent{Type: PaymentEventCreated, Payment: p})
+			if p.Status != PaymentStatusPending && p.Status != "" {
+				events = append(events, PaymentEvent{Type: statusEventType(p.Status), Payment: p})
+			}
+			continue
+		}
+		typ := PaymentEventUpdated
+		if p.Status != prev.Status {
+			typ = statusEventType(p.Status)
+		}
+		events = append(events, PaymentEvent{Type: typ, Payment: p, PreviousStatus: prev.Status})
+	}
+	if !w.cursor.Initialized {
+		w.cursor.Initialized = true
+		w.dirty = true
+	}
+	w.prune()
+	return events, nil
+}
+
+// prune removes terminal payments whose last update is older than Retention,
+// measured against the newest update seen, and advances PrunedBefore past them
+func (w *PaymentWatcher) prune() {
+	var newest time.Time
+	for _, m := range w.cursor.Payments {
+		if t, err := time.Parse(time.RFC3339Nano, m.UpdatedAt); err == nil && t.After(newest) {
+			newest = t
+		}
+	}
+	if newest.IsZero() {
+		return
+	}
+	cutoff := newest.Add(-w.opts.Retention)
+	for id, m := range w.cursor.Payments {
+		t, err := time.Parse(time.RFC3339Nano, m.UpdatedAt)
+		if err != nil || !IsTerminalPaymentStatus(m.Status) || !t.Before(cutoff) {
+			continue
+		}
+		delete(w.cursor.Payments, id)
+		if w.cursor.PrunedBefore == "" || isNewer(m.UpdatedAt, w.cursor.PrunedBefore) {
+			w.cursor.PrunedBefore = m.UpdatedAt
+		}
+		w.dirty = true
+	}
+}
+
+func statusEventType(status string) PaymentEventType {
+	switch status {
+	case PaymentStatusPaid:
+		return PaymentEventPaid
+	case PaymentStatusClosed:
+		return PaymentEventClosed
+	case PaymentStatusExpired:
+		return PaymentEventExpired
+	}
+	return PaymentEventUpdated
+}
+
+// isNewer compares two UpdatedAt timestamps, falling back to string comparison if they are not RFC 3339
+func isNewer(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a)
+	tb, errB := time.Parse(time.RFC3339Nano, b)
+	if errA == nil && errB == nil {
+		return ta.After(tb)
+	}
+	return a > b
+}
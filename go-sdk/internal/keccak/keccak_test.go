@@ -0,0 +1,64 @@
+package keccak
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSum256(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"The quick brown fox jumps over the lazy dog", "4d741b6f1eb29cb2a9b9911c82f56fa8d73b04959d3d9d222895df6c0b28aa15"},
+		// ERC-20 selectors and the Transfer event topic
+		{"transfer(address,uint256)", "a9059cbb2ab09eb219583f4a59a5d0623ade346d962bcd4e46b11da047c9049b"},
+		{"approve(address,uint256)", "095ea7b334ae44009aa867bfb386f5c3b4b443ac6f0ee573fa91c4608fbadfba"},
+		{"Transfer(address,address,uint256)", "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+	}
+	for _, tt := range tests {
+		got := Sum256([]byte(tt.input))
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("Sum256(%q) = %x, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+// sha3Sum256 runs the same sponge with SHA-3 padding, so the permutation and the
+// multi-block absorption can be checked against crypto/sha3 for any length
+func sha3Sum256(data []byte) [32]byte {
+	var state [25]uint64
+	for len(data) >= rate {
+		absorb(&state, data[:rate])
+		data = data[rate:]
+	}
+	var block [rate]byte
+	copy(block[:], data)
+	block[len(data)] ^= 0x06
+	block[rate-1] ^= 0x80
+	absorb(&state, block[:])
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], state[i])
+	}
+	return out
+}
+
+func TestPermutationAgainstSHA3(t *testing.T) {
+	data := make([]byte, 3*rate+7)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	for n := 0; n <= len(data); n++ {
+		got := sha3Sum256(data[:n])
+		want := sha3.Sum256(data[:n])
+		if !bytes.Equal(got[:], want[:]) {
+			t.Fatalf("length %d: got %x, want %x", n, got, want)
+		}
+	}
+}
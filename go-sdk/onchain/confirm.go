@@ -0,0 +1,228 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrReorged is returned by WaitForConfirmations when the receipt of a transaction that was
+// reported as mined stays missing, which means its block was dropped by a chain reorganization
+var ErrReorged = errors.New("transaction receipt disappeared, possible reorg")
+
+// DefaultConfirmations is the threshold used for chains without their own setting
+const DefaultConfirmations = 12
+
+// DefaultReorgMisses is how many polls in a row must miss the receipt before ErrReorged
+const DefaultReorgMisses = 3
+
+// Thresholds is the number of confirmations required before a payment is treated as final
+type Thresholds struct {
+	// Default applies to chains not listed in ByChain, DefaultConfirmations if zero
+	Default int64
+	// ByChain overrides the threshold per chain ID
+	ByChain map[int]int64
+}
+
+// For returns the confirmations required on a chain
+func (t Thresholds) For(chainID int) int64 {
+	if n, ok := t.ByChain[chainID]; ok && n > 0 {
+		return n
+	}
+	if t.Default > 0 {
+		return t.Default
+	}
+	return DefaultConfirmations
+}
+
+// Confirmation is the confirmation state of a transaction at a point in time
+type Confirmation struct {
+	TransactionHash string
+	ChainID         int
+	// ReportedBlock is the block number the payment reported, 0 if unknown
+	ReportedBlock int64
+	// BlockNumber and BlockHash are the block the receipt is in now
+	BlockNumber int64
+	BlockHash   string
+	// Moved is set when the transaction is in a different block than reported
+	Moved         bool
+	Head          int64
+	Confirmations int64
+	Required      int64
+	// Final is true once Confirmations reaches Required
+	Final bool
+}
+
+// ConfirmOptions configures WaitForConfirmations. The zero value polls every 15s.
+type ConfirmOptions struct {
+	// Interval is the delay between polls
+	Interval time.Duration
+	// MaxConsecutiveErrors stops waiting after this many failed polls in a row; 0 retries until ctx ends
+	MaxConsecutiveErrors int
+	// ReorgMisses is how many polls in a row must miss the receipt of a mined transaction
+	// before ErrReorged is returned, DefaultReorgMisses if zero. A replaced block hash is
+	// reported at once.
+	ReorgMisses int
+	// OnUpdate is called every time the number of confirmations or the block changes
+	OnUpdate func(c *Confirmation)
+}
+
+// ConfirmationTracker computes how many confirmations paid payments have,
+// using one JSON-RPC client per chain
+type ConfirmationTracker struct {
+	// RPC maps a chain ID to the JSON-RPC client for that chain
+	RPC map[int]RPCClient
+	// Tokens resolves the chain of a payment from its token ID
+	Tokens     *client.TokenRegistry
+	Thresholds Thresholds
+}
+
+// NewConfirmationTracker creates a tracker. Add a JSON-RPC client per chain with AddChain.
+func NewConfirmationTracker(tokens *client.TokenRegistry, thresholds Thresholds) *ConfirmationTracker {
+	return &ConfirmationTracker{RPC: make(map[int]RPCClient), Tokens: tokens, Thresholds: thresholds}
+}
+
+// AddChain registers the JSON-RPC client used for a chain
+func (t *ConfirmationTracker) AddChain(chainID int, rpc RPCClient) *ConfirmationTracker {
+	t.RPC[chainID] = rpc
+	return t
+}
+
+// Check returns the confirmation state of a transaction on a chain. reportedBlock is the
+// block the transaction was reported in, or 0 if unknown. A missing receipt yields
+// ErrNotFound; nodes behind a load balancer can miss a receipt once, so a single miss
+// is not treated as a reorg.
+func (t *ConfirmationTracker) Check(ctx context.Context, chainID int, txHash string, reportedBlock int64) (*Confirmation, error) {
+	rpc, ok := t.RPC[chainID]
+	if !ok {
+		return nil, fmt.Errorf("no RPC client configured for chain %d", chainID)
+	}
+	conf := &Confirmation{
+		TransactionHash: txHash,
+		ChainID:         chainID,
+		ReportedBlock:   reportedBlock,
+		Required:        t.Thresholds.For(chainID),
+	}
+
+	receipt, err := GetTransactionReceipt(ctx, rpc, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if conf.BlockNumber, err = receipt.Block(); err != nil {
+		return nil, fmt.Errorf("invalid receipt block number: %w", err)
+	}
+	conf.BlockHash = receipt.BlockHash
+	conf.Moved = reportedBlock > 0 && conf.BlockNumber != reportedBlock
+
+	if conf.Head, err = BlockNumber(ctx, rpc); err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	// 包含交易的区块本身算一次确认
+	if conf.Head >= conf.BlockNumber {
+		conf.Confirmations = conf.Head - conf.BlockNumber + 1
+	}
+	conf.Final = conf.Confirmations >= conf.Required
+	return conf, nil
+}
+
+// CheckPayment returns the confirmation state of a paid payment
+func (t *ConfirmationTracker) CheckPayment(ctx context.Context, payment *client.Payment) (*Confirmation, error) {
+	if payment.TransactionHash == "" {
+		return nil, fmt.Errorf("payment %s has no transaction hash", payment.PaymentID)
+	}
+	token, err := t.Tokens.ByID(payment.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	return t.Check(ctx, token.ChainID, payment.TransactionHash, payment.BlockNumber)
+}
+
+// WaitForConfirmations polls until the payment's transaction reaches the confirmation
+// threshold of its chain. It returns ErrReorged when the receipt of the mined transaction
+// is missing for ReorgMisses polls in a row, or when the block it was seen in has been
+// replaced, so the caller can hold the order until the payment is re-checked. If ctx ends
+// first, the last state seen is returned together with the context error.
+func (t *ConfirmationTracker) WaitForConfirmations(ctx context.Context, payment *client.Payment, opts *ConfirmOptions) (*Confirmation, error) {
+	o := ConfirmOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 15 * time.Second
+	}
+	if o.ReorgMisses <= 0 {
+		o.ReorgMisses = DefaultReorgMisses
+	}
+
+	var (
+		last     *Confirmation
+		failures int
+		misses   int
+	)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("waiting for confirmations of payment %s: %w", payment.PaymentID, ctx.Err())
+		case <-timer.C:
+		}
+
+		conf, err := t.CheckPayment(ctx, payment)
+		switch {
+		case errors.Is(err, ErrNotFound) && (payment.BlockNumber > 0 || last != nil):
+			misses++
+			if misses < o.ReorgMisses && !t.blockReplaced(ctx, last) {
+				logrus.Warnf("receipt of payment %s not found (%d/%d)", payment.PaymentID, misses, o.ReorgMisses)
+				break
+			}
+			conf = last
+			if conf == nil {
+				conf = &Confirmation{TransactionHash: payment.TransactionHash, ReportedBlock: payment.BlockNumber}
+			}
+			if o.OnUpdate != nil {
+				o.OnUpdate(conf)
+			}
+			return conf, fmt.Errorf("%s reported in block %d: %w", payment.TransactionHash, payment.BlockNumber, ErrReorged)
+		case err != nil:
+			failures++
+			if o.MaxConsecutiveErrors > 0 && failures >= o.MaxConsecutiveErrors {
+				return last, fmt.Errorf("waiting for confirmations of payment %s: %w", payment.PaymentID, err)
+			}
+			logrus.Warnf("failed to check confirmations of payment %s: %v", payment.PaymentID, err)
+		default:
+			failures, misses = 0, 0
+			if last == nil || last.Confirmations != conf.Confirmations || last.BlockHash != conf.BlockHash {
+				if o.OnUpdate != nil {
+					o.OnUpdate(conf)
+				}
+			}
+			last = conf
+			if conf.Final {
+				return conf, nil
+			}
+		}
+		timer.Reset(o.Interval)
+	}
+}
+
+// blockReplaced reports whether the block last was seen in now has a different hash
+func (t *ConfirmationTracker) blockReplaced(ctx context.Context, last *Confirmation) bool {
+	if last == nil || last.BlockHash == "" {
+		return false
+	}
+	rpc, ok := t.RPC[last.ChainID]
+	if !ok {
+		return false
+	}
+	hash, err := BlockHash(ctx, rpc, last.BlockNumber)
+	if err != nil {
+		return false
+	}
+	return !strings.EqualFold(hash, last.BlockHash)
+}
@@ -0,0 +1,127 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+)
+
+// confirmStub answers receipt polls from a script, one entry per poll, repeating the last entry.
+// A nil entry is a missed receipt. The head stays at block 100 so the payment never becomes final.
+type confirmStub struct {
+	mu        sync.Mutex
+	script    []*Receipt
+	blockHash string
+	polls     int
+}
+
+func (s *confirmStub) handle(method string, params []interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch method {
+	case "eth_getTransactionReceipt":
+		i := min(s.polls, len(s.script)-1)
+		s.polls++
+		if s.script[i] == nil {
+			return nil
+		}
+		return s.script[i]
+	case "eth_blockNumber":
+		return "0x64"
+	case "eth_getBlockByNumber":
+		return map[string]string{"hash": s.blockHash}
+	}
+	return nil
+}
+
+func (s *confirmStub) receiptPolls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polls
+}
+
+func minedReceipt() *Receipt {
+	receipt := paidReceipt()
+	receipt.BlockHash = "0xaaa"
+	return receipt
+}
+
+func newConfirmTracker(t *testing.T, stub *confirmStub) *ConfirmationTracker {
+	tokens := client.NewTokenRegistry(context.Background(), func() (*client.ListTokensResponse, error) {
+		return &client.ListTokensResponse{Tokens: []*client.Token{{TokenID: "token123", ChainID: 1}}}, nil
+	}, time.Hour)
+	return NewConfirmationTracker(tokens, Thresholds{Default: 100}).AddChain(1, newStubRPCFunc(t, stub.handle))
+}
+
+func TestWaitForConfirmationsReorg(t *testing.T) {
+	payment := &client.Payment{
+		PaymentID:       "payment123",
+		TokenID:         "token123",
+		TransactionHash: testTxHash,
+		BlockNumber:     100,
+	}
+
+	tests := []struct {
+		name      string
+		script    []*Receipt
+		blockHash string
+		reorged   bool
+		polls     int // receipt polls expected when ErrReorged is returned
+	}{
+		{
+			name:      "single transient miss",
+			script:    []*Receipt{minedReceipt(), nil, minedReceipt()},
+			blockHash: "0xaaa",
+		},
+		{
+			name:      "receipt missing for ReorgMisses polls",
+			script:    []*Receipt{nil},
+			blockHash: "0xaaa",
+			reorged:   true,
+			polls:     DefaultReorgMisses,
+		},
+		{
+			name:      "block hash replaced",
+			script:    []*Receipt{minedReceipt(), nil},
+			blockHash: "0xbbb",
+			reorged:   true,
+			polls:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &confirmStub{script: tt.script, blockHash: tt.blockHash}
+			tracker := newConfirmTracker(t, stub)
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			conf, err := tracker.WaitForConfirmations(ctx, payment, &ConfirmOptions{Interval: time.Millisecond})
+			if !tt.reorged {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("err = %v, want the context deadline", err)
+				}
+				if stub.receiptPolls() < len(tt.script) {
+					t.Errorf("stopped after %d polls, want at least %d", stub.receiptPolls(), len(tt.script))
+				}
+				if conf == nil || conf.BlockHash != "0xaaa" || conf.Confirmations != 1 {
+					t.Errorf("last confirmation = %+v, want block 0xaaa with 1 confirmation", conf)
+				}
+				return
+			}
+			if !errors.Is(err, ErrReorged) {
+				t.Fatalf("err = %v, want ErrReorged", err)
+			}
+			if got := stub.receiptPolls(); got != tt.polls {
+				t.Errorf("receipt polls = %d, want %d", got, tt.polls)
+			}
+			if conf == nil || conf.TransactionHash != testTxHash {
+				t.Errorf("confirmation = %+v, want the payment's transaction", conf)
+			}
+		})
+	}
+}
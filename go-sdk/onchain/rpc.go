@@ -0,0 +1,186 @@
+// Package onchain verifies Reddio Pay payments directly against an EVM chain
+// through a JSON-RPC endpoint, without trusting the payment status reported by the API.
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrNotFound is returned when the node does not know the requested receipt or block
+var ErrNotFound = errors.New("not found")
+
+// RPCClient performs JSON-RPC calls against an EVM node. It is an interface so that
+// callers can plug in their own transport, load balancing or a test stub.
+type RPCClient interface {
+	Call(ctx context.Context, result interface{}, method string, params ...interface{}) error
+}
+
+// RPCError is an error object returned by the node
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// HTTPRPCClient is an RPCClient that speaks JSON-RPC 2.0 over HTTP
+type HTTPRPCClient struct {
+	endpoint   string
+	httpClient *http.Client
+	nextID     atomic.Int64
+}
+
+// NewHTTPRPCClient creates a JSON-RPC client for the given endpoint URL
+func NewHTTPRPCClient(endpoint string) *HTTPRPCClient {
+	return &HTTPRPCClient{endpoint: endpoint, httpClient: &http.Client{}}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// Call invokes method with params and decodes the result into result.
+// A null result yields ErrNotFound.
+func (c *HTTPRPCClient) Call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	reqBody, err := json.Marshal(&rpcRequest{JSONRPC: "2.0", ID: c.nextID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RPC request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	var response rpcResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if len(response.Result) == 0 || string(response.Result) == "null" {
+		return fmt.Errorf("%s: %w", method, ErrNotFound)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to unmarshal %s result: %w", method, err)
+	}
+	return nil
+}
+
+// Log is an event log in a transaction receipt
+type Log struct {
+	Address  string   `json:"address"`
+	Topics   []string `json:"topics"`
+	Data     string   `json:"data"`
+	LogIndex string   `json:"logIndex"`
+	Removed  bool     `json:"removed"`
+}
+
+// Receipt is a transaction receipt as returned by eth_getTransactionReceipt
+type Receipt struct {
+	TransactionHash string `json:"transactionHash"`
+	BlockHash       string `json:"blockHash"`
+	BlockNumber     string `json:"blockNumber"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Status          string `json:"status"`
+	GasUsed         string `json:"gasUsed"`
+	Logs            []Log  `json:"logs"`
+}
+
+// Block returns the receipt's block number
+func (r *Receipt) Block() (int64, error) {
+	return parseQuantity(r.BlockNumber)
+}
+
+// Succeeded reports whether the transaction executed successfully
+func (r *Receipt) Succeeded() bool {
+	return r.Status == "0x1"
+}
+
+// GetTransactionReceipt fetches a receipt; it returns ErrNotFound for unknown or pending transactions
+func GetTransactionReceipt(ctx context.Context, rpc RPCClient, txHash string) (*Receipt, error) {
+	var receipt Receipt
+	if err := rpc.Call(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// BlockNumber fetches the current chain head height
+func BlockNumber(ctx context.Context, rpc RPCClient) (int64, error) {
+	var head string
+	if err := rpc.Call(ctx, &head, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return parseQuantity(head)
+}
+
+// BlockHash fetches the hash of the canonical block at a height; it returns ErrNotFound above the head
+func BlockHash(ctx context.Context, rpc RPCClient, number int64) (string, error) {
+	var block struct {
+		Hash string `json:"hash"`
+	}
+	if err := rpc.Call(ctx, &block, "eth_getBlockByNumber", "0x"+strconv.FormatInt(number, 16), false); err != nil {
+		return "", err
+	}
+	return block.Hash, nil
+}
+
+// parseQuantity parses a hex encoded JSON-RPC quantity such as "0x1b4"
+func parseQuantity(s string) (int64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return strconv.ParseInt(s[2:], 16, 64)
+}
+
+// parseUint256 parses a hex encoded 32-byte word
+func parseUint256(s string) (*big.Int, error) {
+	s = strings.TrimPrefix(s, "0x")
+	if s == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid uint256 %q", s)
+	}
+	return v, nil
+}
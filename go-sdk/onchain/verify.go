@@ -0,0 +1,257 @@
+package onchain
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/internal/keccak"
+)
+
+// TransferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event
+var TransferTopic = eventTopic("Transfer(address,address,uint256)")
+
+func eventTopic(signature string) string {
+	h := keccak.Sum256([]byte(signature))
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+// Transfer is a token movement found in a receipt
+type Transfer struct {
+	Token  client.Address
+	From   client.Address
+	To     client.Address
+	Amount *big.Int
+}
+
+// LogDecoder extracts transfers from a log that is not a plain ERC-20 Transfer,
+// such as a payment router event. It returns false if it does not recognize the log.
+type LogDecoder func(log Log) ([]Transfer, bool)
+
+// DecodeTransfer decodes an ERC-20 Transfer log
+func DecodeTransfer(log Log) (*Transfer, bool) {
+	if len(log.Topics) != 3 || !strings.EqualFold(log.Topics[0], TransferTopic) {
+		return nil, false
+	}
+	amount, err := parseUint256(log.Data)
+	if err != nil {
+		return nil, false
+	}
+	return &Transfer{
+		Token:  client.Address(log.Address).Checksum(),
+		From:   topicAddress(log.Topics[1]),
+		To:     topicAddress(log.Topics[2]),
+		Amount: amount,
+	}, true
+}
+
+// topicAddress extracts the address stored in the low 20 bytes of an indexed topic
+func topicAddress(topic string) client.Address {
+	t := strings.TrimPrefix(topic, "0x")
+	if len(t) < 40 {
+		return client.Address(topic)
+	}
+	return client.Address("0x" + t[len(t)-40:]).Checksum()
+}
+
+// Expectation is what the payment's transaction must contain. It is usually taken
+// from the ExternalCreatePaymentResponse returned when the payment was created.
+type Expectation struct {
+	// TokenAddress is the ERC-20 contract the payment is made in; required, since only
+	// transfers of this token count. Native currency payments cannot be verified from logs.
+	TokenAddress client.Address
+	// ContractAddress is the payment router; if set, the transaction must be sent to it
+	ContractAddress client.Address
+	// Receivers are the addresses and amounts that must be paid
+	Receivers []*client.PaymentReceiver
+}
+
+// ExpectationFromResponse builds the expectation from the payment creation response
+func ExpectationFromResponse(resp *client.ExternalCreatePaymentResponse) Expectation {
+	return Expectation{
+		TokenAddress:    resp.TokenAddress,
+		ContractAddress: resp.ContractAddress,
+		Receivers:       resp.PaymentReceivers,
+	}
+}
+
+// ReceiverCheck compares what one receiver address should have received with what it did receive
+type ReceiverCheck struct {
+	Types    []string // receiver types at this address, e.g. "merchant" and "fee"
+	Address  client.Address
+	Expected string // base units
+	Received string // base units
+	OK       bool
+}
+
+// Report is the result of verifying a payment on-chain
+type Report struct {
+	PaymentID       string
+	TransactionHash string
+	ReceiptFound    bool
+	TxSucceeded     bool
+	ReportedBlock   int64
+	ReceiptBlock    int64
+	BlockMatches    bool
+	ContractMatches bool
+	Receivers       []ReceiverCheck
+	Transfers       []Transfer
+	// Problems describes every check that failed
+	Problems []string
+	// Verified is true only if every check passed
+	Verified bool
+}
+
+func (r *Report) problem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// Verifier checks paid payments against the chain
+type Verifier struct {
+	RPC RPCClient
+	// Decoders extract transfers from router specific logs in addition to ERC-20 Transfer logs
+	Decoders []LogDecoder
+}
+
+// NewVerifier creates a verifier using the given JSON-RPC client
+func NewVerifier(rpc RPCClient, decoders ...LogDecoder) *Verifier {
+	return &Verifier{RPC: rpc, Decoders: decoders}
+}
+
+// VerifyPayment fetches the receipt of the payment's transaction and checks that it succeeded,
+// was included in the reported block and paid every receiver the expected amount of the expected
+// token. Failed checks are reported in the Report; an error is returned only if the chain could
+// not be queried.
+func (v *Verifier) VerifyPayment(ctx context.Context, payment *client.Payment, exp Expectation) (*Report, error) {
+	if payment.TransactionHash == "" {
+		return nil, fmt.Errorf("payment %s has no transaction hash", payment.PaymentID)
+	}
+	report := &Report{
+		PaymentID:       payment.PaymentID,
+		TransactionHash: payment.TransactionHash,
+		ReportedBlock:   payment.BlockNumber,
+	}
+	if payment.Status != client.PaymentStatusPaid {
+		report.problem("payment status is %q, not %q", payment.Status, client.PaymentStatusPaid)
+	}
+
+	receipt, err := GetTransactionReceipt(ctx, v.RPC, payment.TransactionHash)
+	if errors.Is(err, ErrNotFound) {
+		report.problem("transaction %s not found on chain", payment.TransactionHash)
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.ReceiptFound = true
+	if !strings.EqualFold(receipt.TransactionHash, payment.TransactionHash) {
+		report.problem("node returned receipt for %s, expected %s", receipt.TransactionHash, payment.TransactionHash)
+	}
+
+	report.TxSucceeded = receipt.Succeeded()
+	if !report.TxSucceeded {
+		report.problem("transaction reverted (status %s)", receipt.Status)
+	}
+	if report.ReceiptBlock, err = receipt.Block(); err != nil {
+		report.problem("invalid receipt block number: %v", err)
+	}
+	report.BlockMatches = report.ReceiptBlock == payment.BlockNumber
+	if !report.BlockMatches {
+		report.problem("transaction is in block %d, payment reports block %d", report.ReceiptBlock, payment.BlockNumber)
+	}
+	report.ContractMatches = exp.ContractAddress == "" || exp.ContractAddress.Equal(client.Address(receipt.To))
+	if !report.ContractMatches {
+		report.problem("transaction was sent to %s, expected payment router %s", receipt.To, exp.ContractAddress)
+	}
+
+	report.Transfers = v.decodeTransfers(receipt.Logs)
+	v.checkReceivers(report, exp)
+
+	report.Verified = len(report.Problems) == 0
+	return report, nil
+}
+
+func (v *Verifier) decodeTransfers(logs []Log) []Transfer {
+	var transfers []Transfer
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		if t, ok := DecodeTransfer(log); ok {
+			transfers = append(transfers, *t)
+			continue
+		}
+		for _, decode := range v.Decoders {
+			if ts, ok := decode(log); ok {
+				transfers = append(transfers, ts...)
+				break
+			}
+		}
+	}
+	return transfers
+}
+
+func (v *Verifier) checkReceivers(report *Report, exp Expectation) {
+	// 未指定代币时任何 ERC-20 转账都会被计入，无法验证
+	if exp.TokenAddress.IsZero() {
+		report.problem("no token address to verify against; native currency payments are not supported")
+		return
+	}
+	// 同一地址可能同时是商户和手续费接收方，按地址汇总
+	expected := make(map[string]*ReceiverCheck)
+	want := make(map[string]*big.Int)
+	for _, r := range exp.Receivers {
+		key := strings.ToLower(string(r.RecipientAddress))
+		amount, ok := new(big.Int).SetString(r.Amount, 10)
+		if !ok {
+			report.problem("invalid expected amount %q for %s", r.Amount, r.RecipientAddress)
+			continue
+		}
+		if _, ok := expected[key]; !ok {
+			expected[key] = &ReceiverCheck{Address: r.RecipientAddress.Checksum()}
+			want[key] = new(big.Int)
+		}
+		expected[key].Types = append(expected[key].Types, r.Type)
+		want[key].Add(want[key], amount)
+	}
+
+	got := make(map[string]*big.Int)
+	for _, t := range report.Transfers {
+		if !t.Token.Equal(exp.TokenAddress) {
+			continue
+		}
+		key := strings.ToLower(string(t.To))
+		if got[key] == nil {
+			got[key] = new(big.Int)
+		}
+		got[key].Add(got[key], t.Amount)
+	}
+
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		check := expected[key]
+		received := got[key]
+		if received == nil {
+			received = new(big.Int)
+		}
+		check.Expected = want[key].String()
+		check.Received = received.String()
+		check.OK = received.Cmp(want[key]) == 0
+		if !check.OK {
+			report.problem("%s (%s) received %s, expected %s", check.Address, strings.Join(check.Types, "+"), check.Received, check.Expected)
+		}
+		report.Receivers = append(report.Receivers, *check)
+	}
+	if len(exp.Receivers) == 0 {
+		report.problem("no expected receivers to verify against")
+	}
+}
@@ -0,0 +1,192 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/reddio-com/reddio-pay-sdk/go-sdk/client"
+)
+
+const (
+	testTxHash   = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	testToken    = client.Address("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+	testOther    = client.Address("0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB")
+	testRouter   = client.Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	testMerchant = client.Address("0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb")
+	testFee      = client.Address("0x52908400098527886E0F7030069857D2E4169EE7")
+	testPayer    = client.Address("0x8617E340B3D01FA5F11F306F4090FD50E238070D")
+)
+
+// newStubRPC serves eth_getTransactionReceipt from receipts; unknown hashes return null
+func newStubRPC(t *testing.T, receipts map[string]*Receipt) *HTTPRPCClient {
+	return newStubRPCFunc(t, func(method string, params []interface{}) interface{} {
+		if method != "eth_getTransactionReceipt" {
+			t.Errorf("unexpected method %s", method)
+			return nil
+		}
+		if receipt, ok := receipts[params[0].(string)]; ok {
+			return receipt
+		}
+		return nil
+	})
+}
+
+// newStubRPCFunc serves every call with the result of handle; a nil result is sent as null
+func newStubRPCFunc(t *testing.T, handle func(method string, params []interface{}) interface{}) *HTTPRPCClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result := handle(req.Method, req.Params)
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return NewHTTPRPCClient(srv.URL)
+}
+
+func word(addr client.Address) string {
+	return "0x" + strings.Repeat("0", 24) + strings.ToLower(string(addr)[2:])
+}
+
+func transferLog(token, from, to client.Address, amount int64) Log {
+	return Log{
+		Address: strings.ToLower(string(token)),
+		Topics:  []string{TransferTopic, word(from), word(to)},
+		Data:    fmt.Sprintf("0x%064x", big.NewInt(amount)),
+	}
+}
+
+func paidReceipt(logs ...Log) *Receipt {
+	return &Receipt{
+		TransactionHash: testTxHash,
+		BlockNumber:     "0x64",
+		From:            strings.ToLower(string(testPayer)),
+		To:              strings.ToLower(string(testRouter)),
+		Status:          "0x1",
+		Logs:            logs,
+	}
+}
+
+func TestVerifyPayment(t *testing.T) {
+	payment := &client.Payment{
+		PaymentID:       "payment123",
+		Status:          client.PaymentStatusPaid,
+		TransactionHash: testTxHash,
+		BlockNumber:     100,
+	}
+	exp := Expectation{
+		TokenAddress:    testToken,
+		ContractAddress: testRouter,
+		Receivers: []*client.PaymentReceiver{
+			{Type: "merchant", RecipientAddress: testMerchant, Amount: "990000"},
+			{Type: "fee", RecipientAddress: testFee, Amount: "10000"},
+		},
+	}
+	exact := []Log{
+		transferLog(testToken, testPayer, testMerchant, 990000),
+		transferLog(testToken, testPayer, testFee, 10000),
+	}
+
+	tests := []struct {
+		name     string
+		receipt  *Receipt
+		exp      Expectation
+		verified bool
+		problem  string
+	}{
+		{
+			name:     "exact match",
+			receipt:  paidReceipt(exact...),
+			exp:      exp,
+			verified: true,
+		},
+		{
+			name: "wrong amount",
+			receipt: paidReceipt(
+				transferLog(testToken, testPayer, testMerchant, 900000),
+				transferLog(testToken, testPayer, testFee, 10000),
+			),
+			exp:     exp,
+			problem: "received 900000, expected 990000",
+		},
+		{
+			name: "wrong token",
+			receipt: paidReceipt(
+				transferLog(testOther, testPayer, testMerchant, 990000),
+				transferLog(testOther, testPayer, testFee, 10000),
+			),
+			exp:     exp,
+			problem: "received 0, expected 990000",
+		},
+		{
+			name:    "missing token address",
+			receipt: paidReceipt(exact...),
+			exp:     Expectation{ContractAddress: testRouter, Receivers: exp.Receivers},
+			problem: "no token address",
+		},
+		{
+			name: "reverted transaction",
+			receipt: func() *Receipt {
+				r := paidReceipt()
+				r.Status = "0x0"
+				return r
+			}(),
+			exp:     exp,
+			problem: "transaction reverted",
+		},
+		{
+			name:    "missing receipt",
+			receipt: nil,
+			exp:     exp,
+			problem: "not found on chain",
+		},
+		{
+			name: "block mismatch",
+			receipt: func() *Receipt {
+				r := paidReceipt(exact...)
+				r.BlockNumber = "0x65"
+				return r
+			}(),
+			exp:     exp,
+			problem: "transaction is in block 101, payment reports block 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipts := map[string]*Receipt{}
+			if tt.receipt != nil {
+				receipts[testTxHash] = tt.receipt
+			}
+			v := NewVerifier(newStubRPC(t, receipts))
+			report, err := v.VerifyPayment(context.Background(), payment, tt.exp)
+			if err != nil {
+				t.Fatalf("VerifyPayment: %v", err)
+			}
+			if report.Verified != tt.verified {
+				t.Fatalf("Verified = %v, want %v; problems: %v", report.Verified, tt.verified, report.Problems)
+			}
+			if tt.problem == "" {
+				if len(report.Problems) != 0 {
+					t.Fatalf("unexpected problems: %v", report.Problems)
+				}
+				return
+			}
+			for _, p := range report.Problems {
+				if strings.Contains(p, tt.problem) {
+					return
+				}
+			}
+			t.Fatalf("problems %v do not mention %q", report.Problems, tt.problem)
+		})
+	}
+}
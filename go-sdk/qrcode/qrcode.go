@@ -0,0 +1,402 @@
+// Package qrcode is a small pure-Go QR code encoder for payment links and
+// EIP-681 URIs. It encodes text in byte mode and renders the code as PNG,
+// SVG or text for terminals.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Level is the error correction level of a QR code
+type Level int
+
+// Error correction levels, recovering roughly 7%, 15%, 25% and 30% of the code
+const (
+	Low Level = iota
+	Medium
+	Quartile
+	High
+)
+
+// ErrTooLong is returned when the text does not fit in a version 40 QR code
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// Code is an encoded QR code
+type Code struct {
+	// Version is the QR version, 1 to 40
+	Version int
+	// Size is the number of modules per side, 17 + 4*Version
+	Size  int
+	Level Level
+	// Mask is the data mask pattern that was applied, 0 to 7
+	Mask int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode encodes text at the given error correction level using the smallest version that fits.
+// The level is raised if that is possible without a larger version.
+func Encode(text string, level Level) (*Code, error) {
+	if level < Low || level > High {
+		return nil, fmt.Errorf("qrcode: invalid level %d", level)
+	}
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if dataBits(len(data), v) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+	}
+	// 同一版本下尽量提高纠错等级
+	for l := level + 1; l <= High; l++ {
+		if dataBits(len(data), version) <= numDataCodewords(version, l)*8 {
+			level = l
+		}
+	}
+
+	codewords := encodeData(data, version, level)
+	c := newCode(version, level)
+	c.drawFunctionPatterns()
+	c.drawCodewords(addECCAndInterleave(codewords, version, level))
+
+	best, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); minPenalty < 0 || p < minPenalty {
+			best, minPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR 两次即还原
+	}
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormatBits(best)
+	return c, nil
+}
+
+// Module reports whether the module at column x, row y is dark.
+// Coordinates outside the code, including the quiet zone, are light.
+func (c *Code) Module(x, y int) bool {
+	return x >= 0 && x < c.Size && y >= 0 && y < c.Size && c.modules[y][x]
+}
+
+func newCode(version int, level Level) *Code {
+	size := version*4 + 17
+	c := &Code{Version: version, Size: size, Level: level}
+	c.modules = make([][]bool, size)
+	c.isFunction = make([][]bool, size)
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+	return c
+}
+
+// dataBits is the length of a byte mode segment: mode indicator, character count and data
+func dataBits(n, version int) int {
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	if n >= 1<<countBits {
+		return 1 << 30
+	}
+	return 4 + countBits + 8*n
+}
+
+// encodeData builds the data codewords: the byte mode segment, terminator and padding
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level)
+	var bb bitBuffer
+	bb.append(0x4, 4) // byte mode
+	if version >= 10 {
+		bb.append(len(data), 16)
+	} else {
+		bb.append(len(data), 8)
+	}
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	bb.append(0, min(4, capacity*8-len(bb)))
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	out := make([]byte, capacity)
+	for i, bit := range bb {
+		if bit {
+			out[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+	return out
+}
+
+type bitBuffer []bool
+
+func (bb *bitBuffer) append(val, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, (val>>uint(i))&1 != 0)
+	}
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	pos := alignmentPositions(c.Version)
+	n := len(pos)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			// 跳过与定位图形重叠的三个角
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			c.drawAlignment(pos[i], pos[j])
+		}
+	}
+	c.drawFormatBits(0) // reserve the area, overwritten once the mask is chosen
+	c.drawVersion()
+}
+
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	n := version/7 + 2
+	step := (version*8 + n*3 + 5) / (n*4 - 4) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, version*4+17-7; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// formatLevelBits maps a level to its two format bits
+var formatLevelBits = [4]int{Low: 1, Medium: 0, Quartile: 3, High: 2}
+
+func (c *Code) drawFormatBits(mask int) {
+	data := formatLevelBits[c.Level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.Size-8, true) // dark module
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	rem := c.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.Version<<12 | rem
+	for i := 0; i < 18; i++ {
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the data in the zigzag order, two columns at a time from the bottom right
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y][x] && i < len(data)*8 {
+					c.modules[y][x] = bit(int(data[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			c.modules[y][x] = c.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores the current modules with the four rules of the QR specification
+func (c *Code) penalty() int {
+	const (
+		n1 = 3
+		n2 = 3
+		n3 = 40
+		n4 = 10
+	)
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return c.modules[x][y]
+		}
+		return c.modules[y][x]
+	}
+	finderLike := []bool{true, false, true, true, true, false, true}
+
+	result := 0
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < c.Size; y++ {
+			run := 0
+			for x := 0; x < c.Size; x++ {
+				if x > 0 && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+				} else {
+					run = 1
+				}
+				if run == 5 {
+					result += n1
+				} else if run > 5 {
+					result++
+				}
+			}
+			// 类似定位图形的 1:1:3:1:1 序列，任一侧有 4 个浅色模块
+			for x := 0; x+7 <= c.Size; x++ {
+				match := true
+				for k, dark := range finderLike {
+					if at(x+k, y, vertical) != dark {
+						match = false
+						break
+					}
+				}
+				if match && (c.lightRun(x-4, x, y, vertical) || c.lightRun(x+7, x+11, y, vertical)) {
+					result += n3
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				v := c.modules[y][x]
+				if v == c.modules[y][x+1] && v == c.modules[y+1][x] && v == c.modules[y+1][x+1] {
+					result += n2
+				}
+			}
+		}
+	}
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return result + k*n4
+}
+
+// lightRun reports whether positions [from, to) of a line are light; positions outside the code count as light
+func (c *Code) lightRun(from, to, line int, vertical bool) bool {
+	for i := from; i < to; i++ {
+		if i < 0 || i >= c.Size {
+			continue
+		}
+		if (vertical && c.modules[i][line]) || (!vertical && c.modules[line][i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func bit(v, i int) bool {
+	return (v>>uint(i))&1 != 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
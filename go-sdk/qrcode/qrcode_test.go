@@ -0,0 +1,187 @@
+package qrcode
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" at 1-M, from the ISO/IEC 18004 worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Errorf("ECC codewords = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeCapacity(t *testing.T) {
+	tests := []struct {
+		level   Level
+		version int
+		max     int // bytes in byte mode
+	}{
+		{Low, 1, 17},
+		{Medium, 1, 14},
+		{Quartile, 1, 11},
+		{High, 1, 7},
+		{Low, 40, 2953},
+		{High, 40, 1273},
+	}
+	for _, tt := range tests {
+		c, err := Encode(strings.Repeat("a", tt.max), tt.level)
+		if err != nil {
+			t.Fatalf("level %d, %d bytes: %v", tt.level, tt.max, err)
+		}
+		if c.Version != tt.version || c.Size != 17+4*tt.version {
+			t.Errorf("level %d, %d bytes: version %d size %d, want version %d", tt.level, tt.max, c.Version, c.Size, tt.version)
+		}
+		if tt.version == 40 {
+			continue
+		}
+		if c, _ := Encode(strings.Repeat("a", tt.max+1), tt.level); c.Version != tt.version+1 {
+			t.Errorf("level %d, %d bytes: version %d, want %d", tt.level, tt.max+1, c.Version, tt.version+1)
+		}
+	}
+	if _, err := Encode(strings.Repeat("a", 2954), Low); !errors.Is(err, ErrTooLong) {
+		t.Errorf("2954 bytes: got %v, want ErrTooLong", err)
+	}
+}
+
+func TestEncodeRaisesLevel(t *testing.T) {
+	c, err := Encode("1234567", Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Version != 1 || c.Level != High {
+		t.Errorf("version %d level %d, want version 1 level High", c.Version, c.Level)
+	}
+}
+
+// bchValid reports whether bits is a codeword of the BCH code with the given generator
+func bchValid(bits, dataLen, genLen, gen int) bool {
+	rem := bits
+	for i := dataLen + genLen - 1; i >= genLen; i-- {
+		if rem>>i&1 == 1 {
+			rem ^= gen << (i - genLen)
+		}
+	}
+	return rem == 0
+}
+
+func TestFormatInfo(t *testing.T) {
+	for level := Low; level <= High; level++ {
+		c, err := Encode("ethereum:0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed@1", level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 左上角的格式信息，与 drawFormatBits 的位置一致
+		var first, second int
+		for i := 0; i <= 5; i++ {
+			first |= b2i(c.Module(8, i)) << i
+		}
+		first |= b2i(c.Module(8, 7))<<6 | b2i(c.Module(8, 8))<<7 | b2i(c.Module(7, 8))<<8
+		for i := 9; i < 15; i++ {
+			first |= b2i(c.Module(14-i, 8)) << i
+		}
+		for i := 0; i < 8; i++ {
+			second |= b2i(c.Module(c.Size-1-i, 8)) << i
+		}
+		for i := 8; i < 15; i++ {
+			second |= b2i(c.Module(8, c.Size-15+i)) << i
+		}
+		if first != second {
+			t.Fatalf("level %d: format copies differ: %015b and %015b", level, first, second)
+		}
+		bits := first ^ 0x5412
+		if !bchValid(bits, 5, 10, 0x537) {
+			t.Fatalf("level %d: format bits %015b are not a BCH codeword", level, first)
+		}
+		if got := bits >> 10; got != formatLevelBits[c.Level]<<3|c.Mask {
+			t.Errorf("level %d: format data %05b, want level %d mask %d", level, got, c.Level, c.Mask)
+		}
+		if !c.Module(8, c.Size-8) {
+			t.Errorf("level %d: dark module missing", level)
+		}
+	}
+}
+
+func TestVersionInfo(t *testing.T) {
+	c, err := Encode(strings.Repeat("a", 150), Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Version != 7 {
+		t.Fatalf("version %d, want 7", c.Version)
+	}
+	var upper, lower int
+	for i := 0; i < 18; i++ {
+		upper |= b2i(c.Module(c.Size-11+i%3, i/3)) << i
+		lower |= b2i(c.Module(i/3, c.Size-11+i%3)) << i
+	}
+	if upper != 0x07C94 || lower != 0x07C94 {
+		t.Errorf("version bits %018b and %018b, want %018b", upper, lower, 0x07C94)
+	}
+}
+
+func TestFunctionPatterns(t *testing.T) {
+	c, err := Encode("https://pay.reddio.com/p/payment123", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, corner := range [][2]int{{0, 0}, {c.Size - 7, 0}, {0, c.Size - 7}} {
+		for dy := -1; dy <= 7; dy++ {
+			for dx := -1; dx <= 7; dx++ {
+				d := max(abs(dx-3), abs(dy-3))
+				want := d != 2 && d != 4
+				if got := c.Module(corner[0]+dx, corner[1]+dy); got != want {
+					t.Fatalf("finder at %v: module (%d,%d) = %v, want %v", corner, dx, dy, got, want)
+				}
+			}
+		}
+	}
+	for i := 8; i < c.Size-8; i++ {
+		if c.Module(i, 6) != (i%2 == 0) || c.Module(6, i) != (i%2 == 0) {
+			t.Fatalf("timing pattern broken at %d", i)
+		}
+	}
+}
+
+// Golden digests of the module matrix guard the data placement and mask selection
+func TestEncodeGolden(t *testing.T) {
+	tests := []struct {
+		text  string
+		level Level
+		want  string
+	}{
+		{"https://pay.reddio.com/p/payment123", Medium, "68d46f367038a769d275d3c2e877ae1eb402c780881be9dd0dae582e2f413804"},
+		{"ethereum:0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359@1/transfer?address=0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed&uint256=1000000", Quartile, "b3f0c97a06921adc02abceb8ddd75e76c1617a312f685a8e5a7372d356374ddc"},
+		{strings.Repeat("Reddio Pay ", 40), Low, "295c06a7f42a6aa6c5e17cdd5959ef3ca33724cc78f4004ad51eb482c57d3eb5"},
+	}
+	for _, tt := range tests {
+		c, err := Encode(tt.text, tt.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sb strings.Builder
+		for y := 0; y < c.Size; y++ {
+			for x := 0; x < c.Size; x++ {
+				sb.WriteByte('0' + byte(b2i(c.Module(x, y))))
+			}
+		}
+		sum := sha256.Sum256([]byte(sb.String()))
+		if got := hex.EncodeToString(sum[:]); got != tt.want {
+			t.Errorf("%.30q: version %d mask %d digest %s, want %s", tt.text, c.Version, c.Mask, got, tt.want)
+		}
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// QuietZone is the width in modules of the light border every renderer adds around the code
+const QuietZone = 4
+
+// Image renders the code with scale pixels per module
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for py := 0; py < side; py++ {
+		for px := 0; px < side; px++ {
+			if c.Module(px/scale-QuietZone, py/scale-QuietZone) {
+				img.SetColorIndex(px, py, 1)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the code as a PNG image with scale pixels per module
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
+
+// PNG returns the code as a PNG image with scale pixels per module
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.WritePNG(&buf, scale); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG returns the code as an SVG document with scale user units per module
+func (c *Code) SVG(scale int) string {
+	if scale < 1 {
+		scale = 1
+	}
+	side := c.Size + 2*QuietZone
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, side*scale, side*scale, side, side)
+	sb.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/><path fill="#000000" d="`)
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; {
+			if !c.modules[y][x] {
+				x++
+				continue
+			}
+			// 合并同一行连续的深色模块
+			run := 1
+			for x+run < c.Size && c.modules[y][x+run] {
+				run++
+			}
+			fmt.Fprintf(&sb, "M%d,%dh%dv1h-%dz", x+QuietZone, y+QuietZone, run, run)
+			x += run
+		}
+	}
+	sb.WriteString(`"/></svg>`)
+	return sb.String()
+}
+
+// Terminal renders the code as text using half-block characters, two module rows per line.
+// Dark modules are drawn as blocks, which suits terminals with a light background; set
+// inverse on dark terminals so that the light modules are drawn instead.
+func (c *Code) Terminal(inverse bool) string {
+	blocks := [4]string{" ", "▄", "▀", "█"} // indexed by top<<1 | bottom
+	var sb strings.Builder
+	for y := -QuietZone; y < c.Size+QuietZone; y += 2 {
+		for x := -QuietZone; x < c.Size+QuietZone; x++ {
+			top, bottom := c.Module(x, y), c.Module(x, y+1)
+			if inverse {
+				top, bottom = !top, !bottom
+			}
+			idx := 0
+			if top {
+				idx |= 2
+			}
+			if bottom {
+				idx |= 1
+			}
+			sb.WriteString(blocks[idx])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// String renders the code with Terminal(false)
+func (c *Code) String() string {
+	return c.Terminal(false)
+}